@@ -0,0 +1,239 @@
+package autobrr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// API is the set of Autobrr API operations implemented by Client.
+// Depend on API instead of *Client to substitute fakes in tests or to wrap a
+// client with decorators.
+type API interface {
+	GetFilters() ([]Filter, error)
+	GetFilter(id int64) (*Filter, error)
+	CreateFilter(filter *Filter) (*Filter, error)
+	UpdateFilter(id int64, filter *Filter) (*Filter, error)
+	DeleteFilter(id int64) error
+	ToggleFilterEnabled(id int64, enabled bool) error
+
+	GetReleaseStats() (*ReleaseStats, error)
+	GetRecentReleases() ([]Release, error)
+	GetFilterDownloadStats() ([]FilterDownloadStats, error)
+	DeleteReleases(opts DeleteReleasesOptions) error
+	RetryReleaseAction(releaseID, actionStatusID int64) error
+	ProcessRelease(req ProcessReleaseRequest) ([]ProcessReleaseResult, error)
+
+	GetFeeds() ([]Feed, error)
+	CreateFeed(feed *Feed) (*Feed, error)
+	UpdateFeed(id int64, feed *Feed) (*Feed, error)
+	DeleteFeed(id int64) error
+	ToggleFeedEnabled(id int64, enabled bool) error
+	ForceRunFeed(id int64) error
+	ClearFeedCache(id int64) error
+	GetFeedLatestRun(id int64) (string, error)
+
+	GetNotifications() ([]Notification, error)
+	CreateNotification(notification *Notification) (*Notification, error)
+	UpdateNotification(id int64, notification *Notification) (*Notification, error)
+	DeleteNotification(id int64) error
+	TestNotification(notification *Notification) error
+
+	GetProxies() ([]Proxy, error)
+	GetProxy(id int64) (*Proxy, error)
+	CreateProxy(proxy *Proxy) (*Proxy, error)
+	UpdateProxy(id int64, proxy *Proxy) (*Proxy, error)
+	DeleteProxy(id int64) error
+	ToggleProxyEnabled(id int64, enabled bool) error
+	TestProxy(proxy *Proxy) error
+
+	GetLists() ([]List, error)
+	CreateList(list *List) (*List, error)
+	UpdateList(id int64, list *List) (*List, error)
+	DeleteList(id int64) error
+	RefreshList(id int64) error
+	RefreshAllLists() error
+
+	GetConfig() (*Config, error)
+	UpdateConfig(update ConfigUpdate) error
+	ServerVersion() (string, error)
+	Capabilities() (*Capabilities, error)
+
+	GetIndexers() ([]Indexer, error)
+	GetDownloadClients() ([]DownloadClient, error)
+
+	StreamEvents(ctx context.Context, stream string, opts ...StreamOption) (<-chan Event, error)
+	StreamLogs(ctx context.Context, opts ...StreamOption) (<-chan LogEntry, error)
+	ListLogFiles() ([]LogFile, error)
+	DownloadLogFile(name string, w io.Writer) error
+	OpenRaw(ctx context.Context, endpoint string) (io.ReadCloser, error)
+
+	OnboardingRequired() (bool, error)
+	Onboard(username, password string) error
+	WaitUntilReady(ctx context.Context, interval time.Duration) error
+	Login(username, password string) error
+	Logout() error
+	WhoAmI() (*Session, error)
+
+	GetAPIKeys() ([]APIKey, error)
+	CreateAPIKey(name string) (*APIKey, error)
+	DeleteAPIKey(key string) error
+	RotateAPIKey(name string) (*APIKey, error)
+
+	Health() (*HealthReport, error)
+	TestConnection() error
+}
+
+var _ API = (*Client)(nil)
+
+// Decorator wraps an API implementation with additional behaviour
+type Decorator func(API) API
+
+// Chain wraps api with the given decorators.
+// The first decorator is the outermost, so it sees every call first.
+func Chain(api API, decorators ...Decorator) API {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		api = decorators[i](api)
+	}
+
+	return api
+}
+
+// ErrReadOnly is returned by write operations on a client wrapped with ReadOnly
+var ErrReadOnly = errors.New("autobrr: client is read-only")
+
+// ReadOnly is a Decorator that rejects every write operation with ErrReadOnly
+// without contacting the server. Read operations are passed through.
+func ReadOnly(api API) API {
+	return readOnlyAPI{api}
+}
+
+// readOnlyAPI embeds API so read operations pass through, and overrides every write
+type readOnlyAPI struct {
+	API
+}
+
+func (readOnlyAPI) CreateFilter(*Filter) (*Filter, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateFilter(int64, *Filter) (*Filter, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteFilter(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ToggleFilterEnabled(int64, bool) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteReleases(DeleteReleasesOptions) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) RetryReleaseAction(int64, int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ProcessRelease(ProcessReleaseRequest) ([]ProcessReleaseResult, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) CreateFeed(*Feed) (*Feed, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateFeed(int64, *Feed) (*Feed, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteFeed(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ToggleFeedEnabled(int64, bool) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ForceRunFeed(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ClearFeedCache(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) CreateNotification(*Notification) (*Notification, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateNotification(int64, *Notification) (*Notification, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteNotification(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) TestNotification(*Notification) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) CreateProxy(*Proxy) (*Proxy, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateProxy(int64, *Proxy) (*Proxy, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteProxy(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) ToggleProxyEnabled(int64, bool) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) CreateList(*List) (*List, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateList(int64, *List) (*List, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteList(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) RefreshList(int64) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) RefreshAllLists() error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) UpdateConfig(ConfigUpdate) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) CreateAPIKey(string) (*APIKey, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) DeleteAPIKey(string) error {
+	return ErrReadOnly
+}
+
+func (readOnlyAPI) RotateAPIKey(string) (*APIKey, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnlyAPI) Onboard(string, string) error {
+	return ErrReadOnly
+}
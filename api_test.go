@@ -0,0 +1,183 @@
+package autobrr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// recordingAPI is a decorator that records the name of every call it sees
+type recordingAPI struct {
+	API
+	name  string
+	calls *[]string
+}
+
+func (r recordingAPI) GetFilters() ([]Filter, error) {
+	*r.calls = append(*r.calls, r.name)
+	return r.API.GetFilters()
+}
+
+func TestChain_Order(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/filters": {statusCode: http.StatusOK, responseBody: `[]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/filters"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var calls []string
+	record := func(name string) Decorator {
+		return func(next API) API {
+			return recordingAPI{API: next, name: name, calls: &calls}
+		}
+	}
+
+	api := Chain(client, record("outer"), record("inner"))
+	if _, err := api.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "outer" || calls[1] != "inner" {
+		t.Errorf("Expected calls [outer inner], got %v", calls)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/filters/1": {statusCode: http.StatusOK, responseBody: `{"id":1,"name":"TV"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/filters/1"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	api := Chain(client, ReadOnly)
+
+	filter, err := api.GetFilter(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := api.CreateFilter(filter); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateFilter, got %v", err)
+	}
+	if _, err := api.UpdateFilter(1, filter); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateFilter, got %v", err)
+	}
+	if err := api.DeleteFilter(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteFilter, got %v", err)
+	}
+	if err := api.ToggleFilterEnabled(1, false); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ToggleFilterEnabled, got %v", err)
+	}
+
+	if err := api.DeleteReleases(DeleteReleasesOptions{All: true}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteReleases, got %v", err)
+	}
+	if err := api.RetryReleaseAction(1, 1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RetryReleaseAction, got %v", err)
+	}
+
+	if _, err := api.ProcessRelease(ProcessReleaseRequest{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ProcessRelease, got %v", err)
+	}
+
+	if _, err := api.CreateFeed(&Feed{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateFeed, got %v", err)
+	}
+	if _, err := api.UpdateFeed(1, &Feed{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateFeed, got %v", err)
+	}
+	if err := api.DeleteFeed(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteFeed, got %v", err)
+	}
+	if err := api.ToggleFeedEnabled(1, true); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ToggleFeedEnabled, got %v", err)
+	}
+	if err := api.ForceRunFeed(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ForceRunFeed, got %v", err)
+	}
+	if err := api.ClearFeedCache(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ClearFeedCache, got %v", err)
+	}
+
+	if _, err := api.CreateNotification(&Notification{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateNotification, got %v", err)
+	}
+	if _, err := api.UpdateNotification(1, &Notification{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateNotification, got %v", err)
+	}
+	if err := api.DeleteNotification(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteNotification, got %v", err)
+	}
+	if err := api.TestNotification(&Notification{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from TestNotification, got %v", err)
+	}
+
+	if _, err := api.CreateProxy(&Proxy{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateProxy, got %v", err)
+	}
+	if _, err := api.UpdateProxy(1, &Proxy{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateProxy, got %v", err)
+	}
+	if err := api.DeleteProxy(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteProxy, got %v", err)
+	}
+	if err := api.ToggleProxyEnabled(1, true); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from ToggleProxyEnabled, got %v", err)
+	}
+
+	if _, err := api.CreateList(&List{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateList, got %v", err)
+	}
+	if _, err := api.UpdateList(1, &List{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateList, got %v", err)
+	}
+	if err := api.DeleteList(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteList, got %v", err)
+	}
+	if err := api.RefreshList(1); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RefreshList, got %v", err)
+	}
+	if err := api.RefreshAllLists(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RefreshAllLists, got %v", err)
+	}
+
+	if err := api.UpdateConfig(ConfigUpdate{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from UpdateConfig, got %v", err)
+	}
+
+	if _, err := api.CreateAPIKey("new"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from CreateAPIKey, got %v", err)
+	}
+	if err := api.DeleteAPIKey("key"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from DeleteAPIKey, got %v", err)
+	}
+	if _, err := api.RotateAPIKey("new"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from RotateAPIKey, got %v", err)
+	}
+
+	if err := api.Onboard("admin", "password"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Onboard, got %v", err)
+	}
+
+	// Writes must not reach the server
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Expected only the read request to be made")
+	}
+}
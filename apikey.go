@@ -0,0 +1,136 @@
+package autobrr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// APIKey represents an autobrr API key
+type APIKey struct {
+	Name      string   `json:"name"`
+	Key       string   `json:"key,omitempty"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at,omitempty"`
+}
+
+// APIKey returns the API key sent with every request
+func (c *Client) APIKey() string {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+
+	return c.apiKey
+}
+
+// SetAPIKey replaces the API key sent with every request. It is safe to call
+// while requests are in flight; requests already sent keep the previous key.
+func (c *Client) SetAPIKey(key string) {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+
+	c.apiKey = key
+}
+
+// GetAPIKeys retrieves all API keys
+func (c *Client) GetAPIKeys() ([]APIKey, error) {
+	var keys []APIKey
+	if err := c.doGetJSON("GetAPIKeys", "/api/keys", &keys); err != nil {
+		return nil, fmt.Errorf("get api keys error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateAPIKey creates a new API key. The returned APIKey carries the generated key.
+func (c *Client) CreateAPIKey(name string) (*APIKey, error) {
+	jsonData, err := json.Marshal(APIKey{Name: name, Scopes: []string{}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal api key: %v", err)
+	}
+
+	respData, err := c.doPost("CreateAPIKey", "/api/keys", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("create api key error: %w", err)
+	}
+
+	var key APIKey
+	if err := json.Unmarshal(respData, &key); err != nil {
+		return nil, fmt.Errorf("failed to decode created api key: %v", err)
+	}
+	if key.Key == "" {
+		return nil, fmt.Errorf("create api key error: server returned no key")
+	}
+
+	return &key, nil
+}
+
+// DeleteAPIKey deletes an API key. autobrr identifies keys by the key itself.
+func (c *Client) DeleteAPIKey(key string) error {
+	endpoint := "/api/keys/" + url.PathEscape(key)
+	_, err := c.doDelete("DeleteAPIKey", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete api key error: %w", err)
+	}
+
+	return nil
+}
+
+// RotateAPIKey replaces the client's API key without downtime. It creates a new
+// key named name, verifies it with a request authenticated by the new key alone,
+// switches the client to it and then deletes the old key. If the new key does
+// not work it is deleted again. If only deleting the old key fails, the new key
+// is returned together with the error, and the client keeps using the new key.
+func (c *Client) RotateAPIKey(name string) (*APIKey, error) {
+	oldKey := c.APIKey()
+
+	newKey, err := c.CreateAPIKey(name)
+	if err != nil {
+		return nil, fmt.Errorf("rotate api key error: %w", err)
+	}
+
+	if err := c.verifyAPIKey(newKey.Key); err != nil {
+		if deleteErr := c.DeleteAPIKey(newKey.Key); deleteErr != nil {
+			return nil, fmt.Errorf("rotate api key error: new key failed verification: %v (cleanup failed: %v)", err, deleteErr)
+		}
+		return nil, fmt.Errorf("rotate api key error: new key failed verification: %v", err)
+	}
+
+	c.SetAPIKey(newKey.Key)
+
+	// A client authenticated with a session only has no old key to delete
+	if oldKey != "" {
+		if err := c.DeleteAPIKey(oldKey); err != nil {
+			return newKey, fmt.Errorf("rotate api key error: old key not deleted: %v", err)
+		}
+	}
+
+	return newKey, nil
+}
+
+// verifyAPIKey checks that key is accepted by the server. The request carries
+// only the key: no session cookie is sent and a 401 does not log in again, so a
+// session cannot mask a broken key.
+func (c *Client) verifyAPIKey(key string) error {
+	req, err := c.newRequest(context.Background(), "VerifyAPIKey", "GET", "/api/config", nil, "", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Del("Cookie")
+	req.Header.Set("X-API-Token", key)
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{StatusCode: resp.StatusCode, Body: data}
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package autobrr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetAPIKeys(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/keys": {statusCode: http.StatusOK, responseBody: `[{"name":"default","key":"test-api-key","scopes":[],"created_at":"2024-05-01T10:00:00Z"}]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/keys"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	keys, err := client.GetAPIKeys()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(keys) != 1 || keys[0].Name != "default" || keys[0].Key != "test-api-key" {
+		t.Errorf("Unexpected keys: %+v", keys)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestCreateAndDeleteAPIKey(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/keys":         {statusCode: http.StatusCreated, responseBody: `{"name":"ci","key":"new-key","scopes":[]}`},
+		"/api/keys/new-key": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/keys"},
+		{method: "DELETE", url: "/api/keys/new-key"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	key, err := client.CreateAPIKey("ci")
+	if err != nil {
+		t.Fatalf("Expected no error from CreateAPIKey, got %v", err)
+	}
+	if key.Key != "new-key" {
+		t.Errorf("Expected key 'new-key', got %q", key.Key)
+	}
+
+	if err := client.DeleteAPIKey(key.Key); err != nil {
+		t.Fatalf("Expected no error from DeleteAPIKey, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+// keyServer is a minimal autobrr backend that accepts any key it has issued
+type keyServer struct {
+	mu       sync.Mutex
+	keys     map[string]bool
+	requests []string
+	// rejectNew makes newly created keys fail authentication
+	rejectNew bool
+	// sessionCookies records whether each request carried the session cookie
+	sessionCookies []bool
+}
+
+func (s *keyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Health checks are unauthenticated and not recorded
+	if strings.HasPrefix(r.URL.Path, "/api/healthz/") {
+		fmt.Fprint(w, "OK")
+		return
+	}
+
+	if r.URL.Path == "/api/auth/login" {
+		http.SetCookie(w, &http.Cookie{Name: "user_session", Value: "session", Path: "/"})
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	token := r.Header.Get("X-API-Token")
+	s.requests = append(s.requests, fmt.Sprintf("%s %s [%s]", r.Method, r.URL.Path, token))
+
+	cookie, _ := r.Cookie("user_session")
+	s.sessionCookies = append(s.sessionCookies, cookie != nil)
+
+	if !s.keys[token] && cookie == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/api/keys":
+		key := fmt.Sprintf("key-%d", len(s.keys)+1)
+		s.keys[key] = !s.rejectNew
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"name":"rotated","key":%q,"scopes":[]}`, key)
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/keys/"):
+		delete(s.keys, strings.TrimPrefix(r.URL.Path, "/api/keys/"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/api/config":
+		fmt.Fprint(w, `{"version":"v1.45.0"}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newKeyTestClient(t *testing.T, backend *keyServer) *Client {
+	srv := httptest.NewServer(backend)
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	client, err := NewClient("old-key", u.Hostname(), u.Port(), srv.Client())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	backend := &keyServer{keys: map[string]bool{"old-key": true}}
+	client := newKeyTestClient(t, backend)
+
+	key, err := client.RotateAPIKey("rotated")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.APIKey() != key.Key {
+		t.Errorf("Expected client to use the new key %q, got %q", key.Key, client.APIKey())
+	}
+	if _, ok := backend.keys["old-key"]; ok {
+		t.Error("Expected the old key to be deleted")
+	}
+
+	expected := []string{
+		"POST /api/keys [old-key]",
+		"GET /api/config [key-2]",
+		"DELETE /api/keys/old-key [key-2]",
+	}
+	if fmt.Sprint(backend.requests) != fmt.Sprint(expected) {
+		t.Errorf("Expected requests %v, got %v", expected, backend.requests)
+	}
+}
+
+func TestRotateAPIKey_VerificationFails(t *testing.T) {
+	backend := &keyServer{keys: map[string]bool{"old-key": true}, rejectNew: true}
+	client := newKeyTestClient(t, backend)
+
+	if _, err := client.RotateAPIKey("rotated"); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	// The client switches back, and the unusable key is cleaned up with the old key
+	if client.APIKey() != "old-key" {
+		t.Errorf("Expected client to keep the old key, got %q", client.APIKey())
+	}
+	if !backend.keys["old-key"] {
+		t.Error("Expected the old key to be kept")
+	}
+	if last := backend.requests[len(backend.requests)-1]; last != "DELETE /api/keys/key-2 [old-key]" {
+		t.Errorf("Expected the new key to be deleted, got %v", backend.requests)
+	}
+}
+
+func TestRotateAPIKey_SessionDoesNotMaskBrokenKey(t *testing.T) {
+	backend := &keyServer{keys: map[string]bool{}, rejectNew: true}
+	srv := httptest.NewServer(backend)
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	client, err := NewClientWithOptions("", u.Hostname(), u.Port(), WithHTTPClient(srv.Client()), WithSessionAuth("admin", "secret"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.Login("admin", "secret"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.RotateAPIKey("rotated"); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if client.APIKey() != "" {
+		t.Errorf("Expected client to keep its session only, got key %q", client.APIKey())
+	}
+	if len(backend.keys) != 0 {
+		t.Errorf("Expected the broken key to be deleted, got %v", backend.keys)
+	}
+
+	// The verification request carries the new key alone
+	for i, request := range backend.requests {
+		if strings.HasPrefix(request, "GET /api/config") && backend.sessionCookies[i] {
+			t.Errorf("Expected %q to be sent without the session cookie", request)
+		}
+	}
+}
+
+func TestSetAPIKey_Concurrent(t *testing.T) {
+	backend := &keyServer{keys: map[string]bool{"old-key": true, "other-key": true}}
+	client := newKeyTestClient(t, backend)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := client.TestConnection(); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				client.SetAPIKey("other-key")
+			} else {
+				client.SetAPIKey("old-key")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
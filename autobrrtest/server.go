@@ -0,0 +1,549 @@
+// Package autobrrtest provides an in-memory fake autobrr server for testing code
+// that uses the autobrr client.
+//
+// The fake keeps filters, actions, indexers and download clients in memory,
+// enforces the X-API-Token header, assigns IDs and timestamps like the real
+// server, records every request and supports fault injection.
+package autobrrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	autobrr "github.com/cehbz/autobrr/v2"
+)
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Fault describes a failure injected into matching requests
+type Fault struct {
+	// Method and Path select the requests the fault applies to. Empty values match any request.
+	Method string
+	Path   string
+
+	// Latency delays the response
+	Latency time.Duration
+
+	// StatusCode, when set, is returned with Body instead of handling the request
+	StatusCode int
+	Body       string
+
+	// DropConnection closes the connection without writing a response.
+	// net/http retries idempotent requests once on a reused connection, so
+	// combine it with Times of at least 2 to make such a request fail.
+	DropConnection bool
+
+	// Times limits the fault to the first n matching requests. Zero applies it to every matching request.
+	Times int
+}
+
+// Server is an in-memory fake autobrr server
+type Server struct {
+	// URL is the base URL of the server, e.g. http://127.0.0.1:38423
+	URL string
+
+	// APIKey is the key clients must send in the X-API-Token header
+	APIKey string
+
+	// Version is the server version reported by the config endpoint, "dev" by default.
+	// Set it before making requests to test version-dependent behaviour.
+	Version string
+
+	srv *httptest.Server
+
+	mu              sync.Mutex
+	nextID          int
+	filters         map[int]*autobrr.Filter
+	indexers        map[int]*autobrr.Indexer
+	downloadClients map[int]*autobrr.DownloadClient
+	faults          []*Fault
+	requests        []Request
+}
+
+// NewServer starts a fake autobrr server accepting apiKey.
+// The caller must call Close when finished.
+func NewServer(apiKey string) *Server {
+	s := &Server{
+		APIKey:          apiKey,
+		Version:         "dev",
+		filters:         make(map[int]*autobrr.Filter),
+		indexers:        make(map[int]*autobrr.Indexer),
+		downloadClients: make(map[int]*autobrr.DownloadClient),
+	}
+
+	mux := http.NewServeMux()
+	s.routes(mux)
+
+	s.srv = httptest.NewServer(s.middleware(mux))
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an autobrr client connected to the server with its API key
+func (s *Server) Client() (*autobrr.Client, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	return autobrr.NewClient(s.APIKey, u.Hostname(), u.Port(), s.srv.Client())
+}
+
+// InjectFault adds a fault applied to matching requests
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// ResetRequests clears the recorded requests
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// AddFilter stores a filter, assigning IDs and timestamps, and returns the stored copy
+func (s *Server) AddFilter(filter autobrr.Filter) autobrr.Filter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return clone(*s.storeFilter(&filter, 0))
+}
+
+// Filter returns the stored filter with the given ID
+func (s *Server) Filter(id int) (autobrr.Filter, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.filters[id]
+	if !ok {
+		return autobrr.Filter{}, false
+	}
+
+	return clone(*f), true
+}
+
+// Filters returns all stored filters ordered by ID
+func (s *Server) Filters() []autobrr.Filter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedValues(s.filters)
+}
+
+// AddIndexer stores an indexer, assigning an ID, and returns the stored copy
+func (s *Server) AddIndexer(indexer autobrr.Indexer) autobrr.Indexer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	indexer.ID = s.newID()
+	s.indexers[indexer.ID] = &indexer
+
+	return clone(indexer)
+}
+
+// AddDownloadClient stores a download client, assigning an ID, and returns the stored copy
+func (s *Server) AddDownloadClient(dc autobrr.DownloadClient) autobrr.DownloadClient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dc.ID = s.newID()
+	s.downloadClients[dc.ID] = &dc
+
+	return clone(dc)
+}
+
+// routes registers the API handlers
+func (s *Server) routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/filters", s.listFilters)
+	mux.HandleFunc("POST /api/filters", s.createFilter)
+	mux.HandleFunc("GET /api/filters/{id}", s.getFilter)
+	mux.HandleFunc("PUT /api/filters/{id}", s.updateFilter)
+	mux.HandleFunc("DELETE /api/filters/{id}", s.deleteFilter)
+	mux.HandleFunc("PUT /api/filters/{id}/toggle", s.toggleFilter)
+
+	mux.HandleFunc("GET /api/indexer", s.listIndexers)
+	mux.HandleFunc("GET /api/download_clients", s.listDownloadClients)
+
+	mux.HandleFunc("GET /api/healthz/liveness", s.healthz)
+	mux.HandleFunc("GET /api/healthz/readiness", s.healthz)
+	mux.HandleFunc("GET /api/config", s.getConfig)
+}
+
+// middleware records requests, applies faults and enforces authentication
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		fault := s.matchFault(r)
+		s.mu.Unlock()
+
+		if fault != nil {
+			if fault.Latency > 0 {
+				select {
+				case <-time.After(fault.Latency):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			if fault.DropConnection {
+				if hj, ok := w.(http.Hijacker); ok {
+					if conn, _, err := hj.Hijack(); err == nil {
+						conn.Close()
+						return
+					}
+				}
+				panic(http.ErrAbortHandler)
+			}
+
+			if fault.StatusCode != 0 {
+				w.WriteHeader(fault.StatusCode)
+				io.WriteString(w, fault.Body)
+				return
+			}
+		}
+
+		// Health checks are unauthenticated, like on the real server
+		if r.Header.Get("X-API-Token") != s.APIKey && !strings.HasPrefix(r.URL.Path, "/api/healthz/") {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault returns the first fault matching r and consumes one of its uses.
+// The caller must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" && f.Path != r.URL.Path {
+			continue
+		}
+
+		matched := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+func (s *Server) listFilters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	filters := sortedValues(s.filters)
+	s.mu.Unlock()
+
+	// Like the real server, the list omits actions and external filters
+	for i := range filters {
+		filters[i].Actions = nil
+		filters[i].External = nil
+	}
+
+	writeJSON(w, http.StatusOK, filters)
+}
+
+func (s *Server) getFilter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.filters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "filter not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (s *Server) createFilter(w http.ResponseWriter, r *http.Request) {
+	var filter autobrr.Filter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Name == "" {
+		writeError(w, http.StatusBadRequest, "filter name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, s.storeFilter(&filter, 0))
+}
+
+func (s *Server) updateFilter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var filter autobrr.Filter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.filters[id]; !ok {
+		writeError(w, http.StatusNotFound, "filter not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.storeFilter(&filter, id))
+}
+
+func (s *Server) deleteFilter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.filters[id]; !ok {
+		writeError(w, http.StatusNotFound, "filter not found")
+		return
+	}
+	delete(s.filters, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) toggleFilter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var data struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.filters[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "filter not found")
+		return
+	}
+	f.Enabled = data.Enabled
+	f.UpdatedAt = now()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listIndexers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, sortedValues(s.indexers))
+}
+
+func (s *Server) listDownloadClients(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, sortedValues(s.downloadClients))
+}
+
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, "OK")
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, r *http.Request) {
+	u, _ := url.Parse(s.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	writeJSON(w, http.StatusOK, autobrr.Config{
+		Host:     u.Hostname(),
+		Port:     port,
+		BaseURL:  "/",
+		LogLevel: "INFO",
+		Version:  s.Version,
+	})
+}
+
+// storeFilter saves filter under id, or under a new ID when id is zero,
+// assigning action IDs, timestamps and counts. The caller must hold s.mu.
+func (s *Server) storeFilter(filter *autobrr.Filter, id int) *autobrr.Filter {
+	f := clone(*filter)
+	ts := now()
+
+	if existing, ok := s.filters[id]; ok {
+		f.ID = id
+		f.CreatedAt = existing.CreatedAt
+	} else {
+		f.ID = s.newID()
+		f.CreatedAt = ts
+	}
+	f.UpdatedAt = ts
+
+	f.ActionsCount = len(f.Actions)
+	f.ActionsEnabledCount = 0
+	for i := range f.Actions {
+		if f.Actions[i].ID == 0 {
+			f.Actions[i].ID = int64(s.newID())
+		}
+		f.Actions[i].FilterID = int64(f.ID)
+		if f.Actions[i].Enabled {
+			f.ActionsEnabledCount++
+		}
+	}
+	for i := range f.External {
+		if f.External[i].ID == 0 {
+			f.External[i].ID = s.newID()
+		}
+	}
+
+	// Resolve indexer relations from either representation
+	ids := append([]int(nil), f.IndexerIDs...)
+	for _, idx := range f.Indexers {
+		ids = append(ids, idx.ID)
+	}
+	f.Indexers = nil
+	f.IndexerIDs = nil
+	seen := make(map[int]bool)
+	for _, indexerID := range ids {
+		idx, ok := s.indexers[indexerID]
+		if !ok || seen[indexerID] {
+			continue
+		}
+		seen[indexerID] = true
+		f.Indexers = append(f.Indexers, *idx)
+		f.IndexerIDs = append(f.IndexerIDs, indexerID)
+	}
+
+	s.filters[f.ID] = &f
+
+	return &f
+}
+
+// newID returns the next free ID. The caller must hold s.mu.
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// pathID parses the {id} path value, writing a 400 response when it is invalid
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return 0, false
+	}
+
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"message": msg})
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// clone deep copies v through JSON so stored values never alias caller data
+func clone[T any](v T) T {
+	var out T
+	data, _ := json.Marshal(v)
+	json.Unmarshal(data, &out)
+	return out
+}
+
+// sortedValues returns copies of the map values ordered by key
+func sortedValues[T any](m map[int]*T) []T {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	out := make([]T, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, clone(*m[k]))
+	}
+
+	return out
+}
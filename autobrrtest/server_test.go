@@ -0,0 +1,186 @@
+package autobrrtest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	autobrr "github.com/cehbz/autobrr/v2"
+)
+
+func newTestClient(t *testing.T, s *Server) *autobrr.Client {
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return client
+}
+
+func TestServer_FilterCRUD(t *testing.T) {
+	s := NewServer("test-api-key")
+	defer s.Close()
+
+	indexer := s.AddIndexer(autobrr.Indexer{Name: "BroadcasTheNet", Identifier: "btn"})
+	client := newTestClient(t, s)
+
+	created, err := client.CreateFilter(&autobrr.Filter{
+		Name:       "TV",
+		IndexerIDs: []int{indexer.ID},
+		Actions:    []autobrr.Action{{Name: "qbit", Type: "QBITTORRENT", Enabled: true}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created.ID == 0 || created.CreatedAt == "" || created.UpdatedAt == "" {
+		t.Errorf("Expected ID and timestamps to be assigned, got %+v", created)
+	}
+	if len(created.Actions) != 1 || created.Actions[0].ID == 0 || created.Actions[0].FilterID != int64(created.ID) {
+		t.Errorf("Expected action to be assigned an ID and filter ID, got %+v", created.Actions)
+	}
+	if len(created.Indexers) != 1 || created.Indexers[0].Identifier != "btn" {
+		t.Errorf("Expected indexer relation to be resolved, got %+v", created.Indexers)
+	}
+
+	created.Shows = "Show A"
+	if _, err := client.UpdateFilter(int64(created.ID), created); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.ToggleFilterEnabled(int64(created.ID), true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stored, ok := s.Filter(created.ID)
+	if !ok {
+		t.Fatalf("Expected filter %d to be stored", created.ID)
+	}
+	if stored.Shows != "Show A" || !stored.Enabled || stored.CreatedAt != created.CreatedAt {
+		t.Errorf("Unexpected stored filter: %+v", stored)
+	}
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filters) != 1 || filters[0].Actions != nil || filters[0].ActionsCount != 1 {
+		t.Errorf("Expected list without actions but with counts, got %+v", filters)
+	}
+
+	if err := client.DeleteFilter(int64(created.ID)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.GetFilter(int64(created.ID)); err == nil {
+		t.Error("Expected error fetching deleted filter, got none")
+	}
+}
+
+func TestServer_RequiresAPIKey(t *testing.T) {
+	s := NewServer("test-api-key")
+	defer s.Close()
+
+	u, _ := url.Parse(s.URL)
+	client, err := autobrr.NewClient("wrong-key", u.Hostname(), u.Port(), s.srv.Client())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = client.GetFilters()
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	s := NewServer("test-api-key")
+	defer s.Close()
+	s.Version = "v1.45.0"
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := client.Health()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !report.Reachable || !report.DatabaseOK || !report.Authenticated || report.ServerVersion != "v1.45.0" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	// A bad key is reported as unauthenticated, not as unreachable
+	client.SetAPIKey("wrong-key")
+	report, err = client.Health()
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if !report.Reachable || report.Authenticated {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	s := NewServer("test-api-key")
+	defer s.Close()
+
+	client := newTestClient(t, s)
+
+	s.InjectFault(Fault{Method: "GET", Path: "/api/filters", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	if _, err := client.GetFilters(); err == nil {
+		t.Error("Expected injected status to fail the request")
+	}
+	if _, err := client.GetFilters(); err != nil {
+		t.Errorf("Expected fault to be consumed, got %v", err)
+	}
+
+	s.InjectFault(Fault{Path: "/api/filters", DropConnection: true})
+	if _, err := client.GetFilters(); err == nil {
+		t.Error("Expected dropped connection to fail the request")
+	}
+	s.ClearFaults()
+
+	s.InjectFault(Fault{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	if _, err := client.GetFilters(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected latency of at least 50ms, got %v", elapsed)
+	}
+	s.ClearFaults()
+}
+
+func TestServer_RecordsRequests(t *testing.T) {
+	s := NewServer("test-api-key")
+	defer s.Close()
+
+	client := newTestClient(t, s)
+	if _, err := client.CreateFilter(&autobrr.Filter{Name: "Movies"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	requests := s.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	if requests[0].Method != "POST" || requests[0].Path != "/api/filters" {
+		t.Errorf("Unexpected request: %s %s", requests[0].Method, requests[0].Path)
+	}
+	if requests[0].Header.Get("X-API-Token") != "test-api-key" {
+		t.Errorf("Expected API key header to be recorded")
+	}
+	if len(requests[0].Body) == 0 {
+		t.Errorf("Expected request body to be recorded")
+	}
+
+	s.ResetRequests()
+	if len(s.Requests()) != 0 {
+		t.Errorf("Expected requests to be cleared")
+	}
+}
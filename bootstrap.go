@@ -0,0 +1,172 @@
+package autobrr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultReadyPollInterval is how often WaitUntilReady polls when no interval is given
+const DefaultReadyPollInterval = time.Second
+
+// OnboardingRequired reports whether the server has no user yet and waits for
+// the initial user to be created with Onboard
+func (c *Client) OnboardingRequired() (bool, error) {
+	resp, err := c.roundTrip("OnboardingRequired", "GET", "/api/auth/onboard", nil, "", nil)
+	if resp == nil {
+		return false, fmt.Errorf("check onboarding error: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return true, nil
+	case http.StatusForbidden:
+		// A user already exists
+		return false, nil
+	default:
+		return false, fmt.Errorf("check onboarding error: %w", err)
+	}
+}
+
+// Onboard creates the initial user of a fresh instance
+func (c *Client) Onboard(username, password string) error {
+	data := map[string]string{"username": username, "password": password}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboarding data: %v", err)
+	}
+
+	_, err = c.doPost("Onboard", "/api/auth/onboard", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("onboarding error: %w", err)
+	}
+
+	return nil
+}
+
+// WaitUntilReady polls the liveness and readiness endpoints until both succeed
+// or ctx is done. interval defaults to DefaultReadyPollInterval.
+func (c *Client) WaitUntilReady(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultReadyPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.checkHealthz(ctx, "Liveness", "/api/healthz/liveness")
+		if err == nil {
+			err = c.checkHealthz(ctx, "Readiness", "/api/healthz/readiness")
+		}
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("server not ready: %v (last error: %v)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// BootstrapOptions configures Bootstrap
+type BootstrapOptions struct {
+	// Username and Password are used to create the initial user on a fresh
+	// instance, and to log in to mint an API key
+	Username string
+	Password string
+
+	// APIKey is an existing key, e.g. from a previous run. If it still works no
+	// new key is minted.
+	APIKey string
+
+	// APIKeyName names the minted key, "bootstrap" by default
+	APIKeyName string
+
+	// ReadyTimeout bounds how long to wait for the server to become ready.
+	// Zero waits until ctx is done.
+	ReadyTimeout time.Duration
+
+	// PollInterval is how often readiness is polled, DefaultReadyPollInterval by default
+	PollInterval time.Duration
+
+	// ClientOptions are passed to NewClientWithOptions
+	ClientOptions []Option
+}
+
+// Bootstrap prepares an autobrr instance for automation and returns a Client
+// authenticated with an API key. It waits for the server to become ready,
+// creates the initial user if onboarding is still required, logs in and mints
+// an API key. A supplied APIKey is reused unless the server rejects it; other
+// failures are returned instead of minting a new key. Read the minted key with
+// Client.APIKey to store it for later runs.
+func Bootstrap(ctx context.Context, addr, port string, opts BootstrapOptions) (*Client, error) {
+	client, err := NewClientWithOptions(opts.APIKey, addr, port, opts.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	readyCtx := ctx
+	if opts.ReadyTimeout > 0 {
+		var cancel context.CancelFunc
+		readyCtx, cancel = context.WithTimeout(ctx, opts.ReadyTimeout)
+		defer cancel()
+	}
+	if err := client.WaitUntilReady(readyCtx, opts.PollInterval); err != nil {
+		return nil, fmt.Errorf("bootstrap error: %w", err)
+	}
+
+	if opts.APIKey != "" {
+		report, err := client.Health()
+		switch {
+		case report.Authenticated:
+			return client, nil
+		case report.Unauthorized:
+			// The key was revoked or belongs to another instance; mint a new one
+			client.SetAPIKey("")
+		default:
+			// Minting on transient failures would leave orphaned keys behind
+			return nil, fmt.Errorf("bootstrap error: %w", err)
+		}
+	}
+
+	if opts.Username == "" || opts.Password == "" {
+		return nil, fmt.Errorf("bootstrap error: username and password are required to mint an api key")
+	}
+
+	required, err := client.OnboardingRequired()
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap error: %w", err)
+	}
+	if required {
+		if err := client.Onboard(opts.Username, opts.Password); err != nil {
+			return nil, fmt.Errorf("bootstrap error: %w", err)
+		}
+	}
+
+	if err := client.Login(opts.Username, opts.Password); err != nil {
+		return nil, fmt.Errorf("bootstrap error: %w", err)
+	}
+
+	name := opts.APIKeyName
+	if name == "" {
+		name = "bootstrap"
+	}
+	key, err := client.CreateAPIKey(name)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap error: %w", err)
+	}
+	client.SetAPIKey(key.Key)
+
+	// The client continues with the API key only. A failed logout merely leaves
+	// the session to expire, so it does not fail the bootstrap.
+	_ = client.Logout()
+
+	return client, nil
+}
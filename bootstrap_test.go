@@ -0,0 +1,224 @@
+package autobrr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bootstrapServer is a minimal autobrr backend that starts without a user and
+// only becomes ready after a number of health checks
+type bootstrapServer struct {
+	mu         sync.Mutex
+	notReady   int
+	user       map[string]string
+	sessions   map[string]bool
+	keys       map[string]bool
+	onboarded  bool
+	readyCalls int
+	// configError makes the authenticated config check fail with a server error
+	configError bool
+}
+
+func (s *bootstrapServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/healthz/liveness":
+		if s.readyCalls < s.notReady {
+			s.readyCalls++
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "OK")
+		return
+	case "/api/healthz/readiness":
+		fmt.Fprint(w, "OK")
+		return
+	case "/api/auth/onboard":
+		if s.user != nil {
+			http.Error(w, "onboarding unavailable", http.StatusForbidden)
+			return
+		}
+		if r.Method == "POST" {
+			var creds map[string]string
+			json.NewDecoder(r.Body).Decode(&creds)
+			s.user = creds
+			s.onboarded = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case "/api/auth/login":
+		var creds map[string]string
+		json.NewDecoder(r.Body).Decode(&creds)
+		if s.user == nil || creds["username"] != s.user["username"] || creds["password"] != s.user["password"] {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		s.sessions["session"] = true
+		http.SetCookie(w, &http.Cookie{Name: "user_session", Value: "session", Path: "/"})
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if s.configError && r.URL.Path == "/api/config" {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	cookie, _ := r.Cookie("user_session")
+	authenticated := s.keys[r.Header.Get("X-API-Token")] || (cookie != nil && s.sessions[cookie.Value])
+	if !authenticated {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/keys":
+		key := fmt.Sprintf("key-%d", len(s.keys)+1)
+		s.keys[key] = true
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"name":"bootstrap","key":%q,"scopes":[]}`, key)
+	case "/api/auth/logout":
+		delete(s.sessions, cookie.Value)
+		w.WriteHeader(http.StatusNoContent)
+	case "/api/config":
+		fmt.Fprint(w, `{"version":"v1.45.0"}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newBootstrapServer(t *testing.T, backend *bootstrapServer) (string, string) {
+	if backend.sessions == nil {
+		backend.sessions = map[string]bool{}
+	}
+	if backend.keys == nil {
+		backend.keys = map[string]bool{}
+	}
+
+	srv := httptest.NewServer(backend)
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	return u.Hostname(), u.Port()
+}
+
+func TestBootstrap_FreshInstance(t *testing.T) {
+	backend := &bootstrapServer{notReady: 2}
+	host, port := newBootstrapServer(t, backend)
+
+	client, err := Bootstrap(context.Background(), host, port, BootstrapOptions{
+		Username:     "admin",
+		Password:     "secret",
+		PollInterval: time.Millisecond,
+		ReadyTimeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !backend.onboarded || backend.user["username"] != "admin" {
+		t.Errorf("Expected the initial user to be created, got %v", backend.user)
+	}
+	if client.APIKey() != "key-1" {
+		t.Errorf("Expected the minted key, got %q", client.APIKey())
+	}
+	if len(backend.sessions) != 0 {
+		t.Errorf("Expected the session to be logged out, got %v", backend.sessions)
+	}
+
+	// The client works with the minted key alone
+	if err := client.TestConnection(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestBootstrap_ExistingUser(t *testing.T) {
+	backend := &bootstrapServer{user: map[string]string{"username": "admin", "password": "secret"}}
+	host, port := newBootstrapServer(t, backend)
+
+	client, err := Bootstrap(context.Background(), host, port, BootstrapOptions{Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if backend.onboarded {
+		t.Error("Expected no onboarding for an existing user")
+	}
+	if client.APIKey() == "" {
+		t.Error("Expected a minted key")
+	}
+}
+
+func TestBootstrap_ReusesWorkingKey(t *testing.T) {
+	backend := &bootstrapServer{
+		user: map[string]string{"username": "admin", "password": "secret"},
+		keys: map[string]bool{"stored-key": true},
+	}
+	host, port := newBootstrapServer(t, backend)
+
+	client, err := Bootstrap(context.Background(), host, port, BootstrapOptions{APIKey: "stored-key"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.APIKey() != "stored-key" || len(backend.keys) != 1 {
+		t.Errorf("Expected the stored key to be reused, got %q and keys %v", client.APIKey(), backend.keys)
+	}
+}
+
+func TestBootstrap_RevokedKey(t *testing.T) {
+	backend := &bootstrapServer{user: map[string]string{"username": "admin", "password": "secret"}}
+	host, port := newBootstrapServer(t, backend)
+
+	client, err := Bootstrap(context.Background(), host, port, BootstrapOptions{APIKey: "revoked-key", Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.APIKey() == "revoked-key" || !backend.keys[client.APIKey()] {
+		t.Errorf("Expected a new key to be minted, got %q", client.APIKey())
+	}
+}
+
+func TestBootstrap_TransientFailureKeepsKey(t *testing.T) {
+	backend := &bootstrapServer{
+		user:        map[string]string{"username": "admin", "password": "secret"},
+		keys:        map[string]bool{"stored-key": true},
+		configError: true,
+	}
+	host, port := newBootstrapServer(t, backend)
+
+	if _, err := Bootstrap(context.Background(), host, port, BootstrapOptions{APIKey: "stored-key", Username: "admin", Password: "secret"}); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if len(backend.keys) != 1 {
+		t.Errorf("Expected no key to be minted, got %v", backend.keys)
+	}
+}
+
+func TestWaitUntilReady_Timeout(t *testing.T) {
+	backend := &bootstrapServer{notReady: 1000}
+	host, port := newBootstrapServer(t, backend)
+
+	client, err := NewClient("", host, port)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := client.WaitUntilReady(ctx, time.Millisecond); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
@@ -0,0 +1,171 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithFilterCache enables a read-through cache for GetFilters and GetFilter.
+// Cached responses are served for ttl. Once expired, entries are revalidated
+// with If-None-Match when the server sent an ETag, so unchanged data is not
+// transferred again; with a ttl of zero every call is revalidated this way.
+// CreateFilter, UpdateFilter, DeleteFilter and ToggleFilterEnabled invalidate
+// the cache, and concurrent requests for the same data share a single fetch.
+func WithFilterCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.filterCache = newResponseCache(ttl)
+	}
+}
+
+// InvalidateFilterCache drops all cached filter responses, e.g. after filters were
+// changed outside this client. It is a no-op when caching is disabled.
+func (c *Client) InvalidateFilterCache() {
+	if c.filterCache != nil {
+		c.filterCache.invalidate()
+	}
+}
+
+// doCachedGet performs a GET request through the filter cache when it is enabled
+func (c *Client) doCachedGet(op, endpoint string) ([]byte, error) {
+	if c.filterCache == nil {
+		return c.doGet(op, endpoint)
+	}
+
+	return c.filterCache.get(endpoint, func(etag string) ([]byte, string, bool, error) {
+		var header http.Header
+		if etag != "" {
+			header = http.Header{"If-None-Match": {etag}}
+		}
+
+		resp, err := c.send(op, "GET", endpoint, nil, "", header)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return resp.Body, resp.Header.Get("ETag"), resp.StatusCode == http.StatusNotModified, nil
+	})
+}
+
+// doCachedGetJSON decodes a GET response into v, through the filter cache when
+// it is enabled and while it is read otherwise
+func (c *Client) doCachedGetJSON(op, endpoint string, v interface{}) error {
+	if c.filterCache == nil {
+		return c.doGetJSON(op, endpoint, v)
+	}
+
+	data, err := c.doCachedGet(op, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return nil
+}
+
+// responseCache caches raw response bodies by endpoint
+type responseCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*cacheEntry
+	calls      map[string]*cacheCall
+	generation uint64
+}
+
+// cacheEntry is a cached response body
+type cacheEntry struct {
+	data    []byte
+	etag    string
+	expires time.Time
+}
+
+// cacheCall is an in-flight fetch shared by concurrent callers
+type cacheCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// cacheFetch fetches a fresh response, sending etag for revalidation when non-empty.
+// It reports notModified when the server confirmed the cached response is still current.
+type cacheFetch func(etag string) (data []byte, newETag string, notModified bool, err error)
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+		calls:   make(map[string]*cacheCall),
+	}
+}
+
+// get returns the cached response for key, fetching it when missing or expired.
+// The returned slice is shared and must not be modified.
+func (rc *responseCache) get(key string, fetch cacheFetch) ([]byte, error) {
+	rc.mu.Lock()
+	entry, cached := rc.entries[key]
+	if cached && rc.now().Before(entry.expires) {
+		rc.mu.Unlock()
+		return entry.data, nil
+	}
+	if call, ok := rc.calls[key]; ok {
+		rc.mu.Unlock()
+		<-call.done
+		return call.data, call.err
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	rc.calls[key] = call
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	var etag string
+	if cached {
+		etag = entry.etag
+	}
+
+	data, newETag, notModified, err := fetch(etag)
+	if err == nil && notModified {
+		data = entry.data
+		if newETag == "" {
+			newETag = etag
+		}
+	}
+
+	rc.mu.Lock()
+	if rc.calls[key] == call {
+		delete(rc.calls, key)
+	}
+	// Results fetched across an invalidation may predate the write that caused it
+	if err == nil && generation == rc.generation {
+		rc.entries[key] = &cacheEntry{
+			data:    data,
+			etag:    newETag,
+			expires: rc.now().Add(rc.ttl),
+		}
+	}
+	rc.mu.Unlock()
+
+	call.data, call.err = data, err
+	close(call.done)
+
+	return data, err
+}
+
+// invalidate drops all entries. Fetches already in flight are not cached and
+// later callers do not join them.
+func (rc *responseCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	rc.entries = make(map[string]*cacheEntry)
+	rc.calls = make(map[string]*cacheCall)
+}
@@ -0,0 +1,158 @@
+package autobrr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCacheTestServer serves a fixed filter list, counting GET requests and
+// optionally supporting ETag revalidation
+func newCacheTestServer(t *testing.T, etag string, delay time.Duration) (*httptest.Server, *int32, *int32) {
+	var gets, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		atomic.AddInt32(&gets, 1)
+		time.Sleep(delay)
+
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.Write([]byte(`[{"id":1,"name":"TV"}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &gets, &notModified
+}
+
+func newCachingClient(t *testing.T, srv *httptest.Server, ttl time.Duration) *Client {
+	u, _ := url.Parse(srv.URL)
+	client, err := NewClientWithOptions("test-api-key", u.Hostname(), u.Port(),
+		WithHTTPClient(srv.Client()),
+		WithFilterCache(ttl),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client
+}
+
+func TestFilterCache_TTL(t *testing.T) {
+	srv, gets, _ := newCacheTestServer(t, "", 0)
+	client := newCachingClient(t, srv, time.Minute)
+
+	now := time.Now()
+	client.filterCache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		filters, err := client.GetFilters()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(filters) != 1 {
+			t.Fatalf("Expected 1 filter, got %d", len(filters))
+		}
+	}
+	if n := atomic.LoadInt32(gets); n != 1 {
+		t.Errorf("Expected 1 request within TTL, got %d", n)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n := atomic.LoadInt32(gets); n != 2 {
+		t.Errorf("Expected expired entry to be refetched, got %d requests", n)
+	}
+}
+
+func TestFilterCache_InvalidatedByWrites(t *testing.T) {
+	srv, gets, _ := newCacheTestServer(t, "", 0)
+	client := newCachingClient(t, srv, time.Hour)
+
+	writes := []func() error{
+		func() error { _, err := client.CreateFilter(&Filter{Name: "New"}); return err },
+		func() error { _, err := client.UpdateFilter(1, &Filter{Name: "TV"}); return err },
+		func() error { return client.DeleteFilter(1) },
+		func() error { return client.ToggleFilterEnabled(1, true) },
+	}
+
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i, write := range writes {
+		// CreateFilter and UpdateFilter decode an empty body, which is fine to ignore here
+		_ = write()
+
+		if _, err := client.GetFilters(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if n := atomic.LoadInt32(gets); int(n) != i+2 {
+			t.Errorf("Expected write %d to invalidate the cache, got %d requests", i, n)
+		}
+	}
+}
+
+func TestFilterCache_ETagRevalidation(t *testing.T) {
+	srv, gets, notModified := newCacheTestServer(t, `"v1"`, 0)
+	client := newCachingClient(t, srv, 0)
+
+	for i := 0; i < 3; i++ {
+		filters, err := client.GetFilters()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(filters) != 1 || filters[0].Name != "TV" {
+			t.Fatalf("Expected cached filter to be returned, got %+v", filters)
+		}
+	}
+
+	if n := atomic.LoadInt32(gets); n != 3 {
+		t.Errorf("Expected every call to revalidate with a zero TTL, got %d requests", n)
+	}
+	if n := atomic.LoadInt32(notModified); n != 2 {
+		t.Errorf("Expected 2 not modified responses, got %d", n)
+	}
+}
+
+func TestFilterCache_StampedeProtection(t *testing.T) {
+	srv, gets, _ := newCacheTestServer(t, "", 50*time.Millisecond)
+	client := newCachingClient(t, srv, time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetFilters(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if n := atomic.LoadInt32(gets); n != 1 {
+		t.Errorf("Expected concurrent callers to share 1 request, got %d", n)
+	}
+}
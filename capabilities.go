@@ -0,0 +1,170 @@
+package autobrr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Feature is an optional part of the autobrr API that is only available from a certain server version
+type Feature string
+
+const (
+	// FeaturePatchFilters is partial filter updates with PATCH /api/filters/{id}
+	FeaturePatchFilters Feature = "patch-filters"
+	// FeatureMusicFields is the record label fields on filters
+	FeatureMusicFields Feature = "music-fields"
+	// FeatureProxies is proxy management
+	FeatureProxies Feature = "proxies"
+	// FeatureReleaseProcess is manual announce injection with ProcessRelease
+	FeatureReleaseProcess Feature = "release-process"
+	// FeatureLists is list management
+	FeatureLists Feature = "lists"
+	// FeatureReleaseProfiles is release duplicate profiles
+	FeatureReleaseProfiles Feature = "release-profiles"
+)
+
+// featureVersions is the first autobrr release that shipped each feature
+var featureVersions = map[Feature]string{
+	FeaturePatchFilters:    "v1.0.0",
+	FeatureMusicFields:     "v1.46.0",
+	FeatureProxies:         "v1.43.0",
+	FeatureReleaseProcess:  "v1.47.0",
+	FeatureLists:           "v1.50.0",
+	FeatureReleaseProfiles: "v1.53.0",
+}
+
+// Capabilities describes the optional features supported by the server.
+// Development builds and servers with an unrecognised version are assumed to support everything.
+type Capabilities struct {
+	// Version is the version reported by the server
+	Version string
+
+	PatchFilters    bool
+	MusicFields     bool
+	Proxies         bool
+	ReleaseProcess  bool
+	Lists           bool
+	ReleaseProfiles bool
+}
+
+// Supports reports whether the server supports feature.
+// Unknown features are reported as supported.
+func (caps Capabilities) Supports(feature Feature) bool {
+	return supportsFeature(caps.Version, feature)
+}
+
+// ErrUnsupportedByServer is returned, possibly wrapped in an *UnsupportedError,
+// by operations the server is too old to support
+var ErrUnsupportedByServer = errors.New("autobrr: operation not supported by server")
+
+// UnsupportedError reports an operation that needs a newer server.
+// It matches ErrUnsupportedByServer with errors.Is.
+type UnsupportedError struct {
+	Operation     string
+	Feature       Feature
+	ServerVersion string
+	MinVersion    string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("autobrr: %s requires autobrr %s or later, server is %s", e.Operation, e.MinVersion, e.ServerVersion)
+}
+
+// Is makes errors.Is(err, ErrUnsupportedByServer) true for an *UnsupportedError
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupportedByServer
+}
+
+// WithServerVersion sets the server version instead of discovering it from the
+// server config on first use, e.g. "v1.45.0"
+func WithServerVersion(version string) Option {
+	return func(c *Client) {
+		c.serverVersion = version
+	}
+}
+
+// Capabilities reports the optional features supported by the server, based on
+// its version. The version is discovered once and cached; see ServerVersion.
+func (c *Client) Capabilities() (*Capabilities, error) {
+	version, err := c.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Capabilities{
+		Version:         version,
+		PatchFilters:    supportsFeature(version, FeaturePatchFilters),
+		MusicFields:     supportsFeature(version, FeatureMusicFields),
+		Proxies:         supportsFeature(version, FeatureProxies),
+		ReleaseProcess:  supportsFeature(version, FeatureReleaseProcess),
+		Lists:           supportsFeature(version, FeatureLists),
+		ReleaseProfiles: supportsFeature(version, FeatureReleaseProfiles),
+	}, nil
+}
+
+// require returns an *UnsupportedError if the server is too old for feature.
+// The server version is discovered on first use. If that fails the operation is
+// let through, so it reports its own error; discovery is then retried only after
+// versionRetryInterval.
+func (c *Client) require(op string, feature Feature) error {
+	version, err := c.ServerVersion()
+	if err != nil || supportsFeature(version, feature) {
+		return nil
+	}
+
+	return &UnsupportedError{
+		Operation:     op,
+		Feature:       feature,
+		ServerVersion: version,
+		MinVersion:    featureVersions[feature],
+	}
+}
+
+// supportsFeature reports whether a server with the given version supports feature
+func supportsFeature(version string, feature Feature) bool {
+	minVersion, ok := featureVersions[feature]
+	if !ok {
+		return true
+	}
+
+	server, ok := parseVersion(version)
+	if !ok {
+		return true
+	}
+	required, _ := parseVersion(minVersion)
+
+	for i := range server {
+		if server[i] != required[i] {
+			return server[i] > required[i]
+		}
+	}
+
+	return true
+}
+
+// parseVersion parses "v1.45.0", "1.45" and "v1.45.0-rc1" into major, minor and patch.
+// Development builds such as "dev" are not parsed.
+func parseVersion(version string) ([3]int, bool) {
+	var parsed [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return parsed, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+
+	return parsed, true
+}
@@ -0,0 +1,188 @@
+package autobrr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		version string
+		feature Feature
+		want    bool
+	}{
+		{"v1.50.0", FeatureLists, true},
+		{"v1.51.2", FeatureLists, true},
+		{"1.50", FeatureLists, true},
+		{"v1.50.0-rc1", FeatureLists, true},
+		{"v1.49.9", FeatureLists, false},
+		{"v0.99.0", FeatureProxies, false},
+		{"v2.0.0", FeatureReleaseProfiles, true},
+		{"v1.52.9", FeatureReleaseProfiles, false},
+		{"v1.46.0", FeatureMusicFields, true},
+		{"v1.45.0", FeatureMusicFields, false},
+		{"v1.0.0", FeaturePatchFilters, true},
+		{"dev", FeatureLists, true},
+		{"", FeatureLists, true},
+		{"v1.0.0", Feature("unknown"), true},
+	}
+
+	for _, tt := range tests {
+		if got := supportsFeature(tt.version, tt.feature); got != tt.want {
+			t.Errorf("supportsFeature(%q, %q) = %v, want %v", tt.version, tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusOK, responseBody: `{"version":"v1.45.0"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	caps, err := client.Capabilities()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Capabilities{Version: "v1.45.0", PatchFilters: true, Proxies: true}
+	if *caps != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *caps)
+	}
+	if caps.Supports(FeatureLists) {
+		t.Error("Expected lists to be unsupported")
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestUnsupportedByServer(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusOK, responseBody: `{"version":"v1.45.0"}`},
+	}
+	// The version is discovered once; the lists endpoint is never called
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = client.GetLists()
+	if !errors.Is(err, ErrUnsupportedByServer) {
+		t.Fatalf("Expected ErrUnsupportedByServer, got %v", err)
+	}
+
+	var unsupported *UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Operation != "GetLists" || unsupported.MinVersion != "v1.50.0" || unsupported.ServerVersion != "v1.45.0" {
+		t.Errorf("Unexpected error details: %+v", unsupported)
+	}
+
+	if err := client.RefreshAllLists(); !errors.Is(err, ErrUnsupportedByServer) {
+		t.Errorf("Expected ErrUnsupportedByServer from RefreshAllLists, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestWithServerVersion(t *testing.T) {
+	client, err := NewClientWithOptions("test-api-key", "localhost", "7474", WithServerVersion("v1.40.0"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The pinned version is used without contacting the server
+	caps, err := client.Capabilities()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if caps.Version != "v1.40.0" || caps.Proxies {
+		t.Errorf("Unexpected capabilities: %+v", caps)
+	}
+
+	if err := client.TestProxy(&Proxy{}); !errors.Is(err, ErrUnsupportedByServer) {
+		t.Errorf("Expected ErrUnsupportedByServer from TestProxy, got %v", err)
+	}
+}
+
+func TestServerVersion_FailedDiscoveryBacksOff(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusInternalServerError, responseBody: "internal error"},
+		"/api/lists":  {statusCode: http.StatusOK, responseBody: `[]`},
+	}
+	// The config is requested once; later gated calls reuse the failed result
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+		{method: "GET", url: "/api/lists"},
+		{method: "GET", url: "/api/lists"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetLists(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if _, err := client.ServerVersion(); err == nil {
+		t.Error("Expected the cached discovery error, got none")
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestServerVersion_EmptyVersionBacksOff(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusOK, responseBody: `{"host":"0.0.0.0"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if version, err := client.ServerVersion(); err != nil || version != "" {
+			t.Fatalf("Expected an empty version, got %q and %v", version, err)
+		}
+	}
+	if mockTransport.requestIndex != 1 {
+		t.Errorf("Expected a single config request, got %d", mockTransport.requestIndex)
+	}
+
+	// Discovery is retried once the backoff has passed
+	client.versionRetryAt = time.Now().Add(-time.Second)
+	if _, err := client.ServerVersion(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Expected discovery to be retried")
+	}
+}
@@ -2,18 +2,58 @@ package autobrr
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 )
 
 // Client is used to interact with the Autobrr API
 type Client struct {
-	client  *http.Client
-	baseURL string
-	apiKey  string
+	client      *http.Client
+	baseURL     string
+	keyMu       sync.RWMutex
+	apiKey      string
+	middleware  []Middleware
+	doer        Doer
+	metrics     MetricsCollector
+	filterCache *responseCache
+	session     *sessionAuth
+
+	versionMu      sync.Mutex
+	serverVersion  string
+	versionRetryAt time.Time
+	versionErr     error
+
+	maxResponseSize int64
+
+	dialContext DialContextFunc
+	unixSocket  string
+}
+
+// Option configures a Client created with NewClientWithOptions
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.client = httpClient
+		}
+	}
+}
+
+// WithMiddleware appends middleware to the chain wrapping every request.
+// The first middleware is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
 }
 
 // Filter represents an Autobrr filter
@@ -156,7 +196,7 @@ type Indexer struct {
 	Implementation     string                 `json:"implementation"`
 	BaseURL            string                 `json:"base_url"`
 	UseProxy           bool                   `json:"use_proxy"`
-	Proxy              interface{}            `json:"proxy"`
+	Proxy              *Proxy                 `json:"proxy"`
 	ProxyID            int                    `json:"proxy_id"`
 	Settings           map[string]interface{} `json:"settings"`
 }
@@ -179,31 +219,43 @@ type FilterListResponse struct {
 // If httpClient is nil, http.DefaultClient is used.
 func NewClient(apiKey, addr, port string, httpClient ...*http.Client) (*Client, error) {
 	// Use the provided http.Client if given, otherwise use http.DefaultClient
-	client := http.DefaultClient
-	if len(httpClient) > 0 && httpClient[0] != nil {
-		client = httpClient[0]
+	var opts []Option
+	if len(httpClient) > 0 {
+		opts = append(opts, WithHTTPClient(httpClient[0]))
 	}
 
-	// Create and return the Client instance
+	return NewClientWithOptions(apiKey, addr, port, opts...)
+}
+
+// NewClientWithOptions initializes a new Autobrr client configured by opts
+func NewClientWithOptions(apiKey, addr, port string, opts ...Option) (*Client, error) {
 	abClient := &Client{
-		client:  client,
-		baseURL: fmt.Sprintf("http://%s:%s", addr, port),
+		client:  http.DefaultClient,
 		apiKey:  apiKey,
+		session: newSessionAuth(),
+
+		maxResponseSize: DefaultMaxResponseSize,
 	}
 
+	for _, opt := range opts {
+		opt(abClient)
+	}
+
+	abClient.baseURL = baseURLFor(addr, port, abClient.unixSocket != "")
+	if err := abClient.applyDialer(); err != nil {
+		return nil, err
+	}
+
+	abClient.doer = chainMiddleware(abClient.client, abClient.middleware)
+
 	return abClient, nil
 }
 
 // GetFilters retrieves all filters
 func (c *Client) GetFilters() ([]Filter, error) {
-	respData, err := c.doGet("/api/filters")
-	if err != nil {
-		return nil, fmt.Errorf("get filters error: %v", err)
-	}
-
 	var response []Filter
-	if err := json.Unmarshal(respData, &response); err != nil {
-		return nil, fmt.Errorf("failed to decode filters response: %v", err)
+	if err := c.doCachedGetJSON("GetFilters", "/api/filters", &response); err != nil {
+		return nil, fmt.Errorf("get filters error: %w", err)
 	}
 
 	return response, nil
@@ -212,14 +264,9 @@ func (c *Client) GetFilters() ([]Filter, error) {
 // GetFilter retrieves a specific filter by ID
 func (c *Client) GetFilter(id int64) (*Filter, error) {
 	endpoint := fmt.Sprintf("/api/filters/%d", id)
-	respData, err := c.doGet(endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("get filter error: %v", err)
-	}
-
 	var filter Filter
-	if err := json.Unmarshal(respData, &filter); err != nil {
-		return nil, fmt.Errorf("failed to decode filter response: %v", err)
+	if err := c.doCachedGetJSON("GetFilter", endpoint, &filter); err != nil {
+		return nil, fmt.Errorf("get filter error: %w", err)
 	}
 
 	return &filter, nil
@@ -232,9 +279,10 @@ func (c *Client) CreateFilter(filter *Filter) (*Filter, error) {
 		return nil, fmt.Errorf("failed to marshal filter: %v", err)
 	}
 
-	respData, err := c.doPost("/api/filters", bytes.NewReader(jsonData), "application/json")
+	respData, err := c.doPost("CreateFilter", "/api/filters", bytes.NewReader(jsonData), "application/json")
+	c.InvalidateFilterCache()
 	if err != nil {
-		return nil, fmt.Errorf("create filter error: %v", err)
+		return nil, fmt.Errorf("create filter error: %w", err)
 	}
 
 	var createdFilter Filter
@@ -253,9 +301,10 @@ func (c *Client) UpdateFilter(id int64, filter *Filter) (*Filter, error) {
 	}
 
 	endpoint := fmt.Sprintf("/api/filters/%d", id)
-	respData, err := c.doPut(endpoint, bytes.NewReader(jsonData), "application/json")
+	respData, err := c.doPut("UpdateFilter", endpoint, bytes.NewReader(jsonData), "application/json")
+	c.InvalidateFilterCache()
 	if err != nil {
-		return nil, fmt.Errorf("update filter error: %v", err)
+		return nil, fmt.Errorf("update filter error: %w", err)
 	}
 
 	var updatedFilter Filter
@@ -269,9 +318,10 @@ func (c *Client) UpdateFilter(id int64, filter *Filter) (*Filter, error) {
 // DeleteFilter deletes a filter by ID
 func (c *Client) DeleteFilter(id int64) error {
 	endpoint := fmt.Sprintf("/api/filters/%d", id)
-	_, err := c.doDelete(endpoint)
+	_, err := c.doDelete("DeleteFilter", endpoint)
+	c.InvalidateFilterCache()
 	if err != nil {
-		return fmt.Errorf("delete filter error: %v", err)
+		return fmt.Errorf("delete filter error: %w", err)
 	}
 
 	return nil
@@ -287,18 +337,19 @@ func (c *Client) ToggleFilterEnabled(id int64, enabled bool) error {
 		return fmt.Errorf("failed to marshal toggle data: %v", err)
 	}
 
-	_, err = c.doPut(endpoint, bytes.NewReader(jsonData), "application/json")
+	_, err = c.doPut("ToggleFilterEnabled", endpoint, bytes.NewReader(jsonData), "application/json")
+	c.InvalidateFilterCache()
 	if err != nil {
-		return fmt.Errorf("toggle filter error: %v", err)
+		return fmt.Errorf("toggle filter error: %w", err)
 	}
 
 	return nil
 }
 
-// TestConnection verifies the connection to Autobrr
+// TestConnection verifies that the server is reachable and ready and that the
+// client is authenticated. See Health for the individual checks.
 func (c *Client) TestConnection() error {
-	_, err := c.doGet("/api/filters")
-	if err != nil {
+	if _, err := c.Health(); err != nil {
 		return fmt.Errorf("connection test failed: %v", err)
 	}
 
@@ -306,61 +357,224 @@ func (c *Client) TestConnection() error {
 }
 
 // doGet is a helper method for making GET requests to the Autobrr API
-func (c *Client) doGet(endpoint string) ([]byte, error) {
-	return c.doRequest("GET", endpoint, nil, "")
+func (c *Client) doGet(op, endpoint string) ([]byte, error) {
+	return c.doRequest(op, "GET", endpoint, nil, "")
 }
 
 // doPost is a helper method for making POST requests to the Autobrr API
-func (c *Client) doPost(endpoint string, body io.Reader, contentType string) ([]byte, error) {
-	return c.doRequest("POST", endpoint, body, contentType)
+func (c *Client) doPost(op, endpoint string, body io.Reader, contentType string) ([]byte, error) {
+	return c.doRequest(op, "POST", endpoint, body, contentType)
 }
 
 // doPut is a helper method for making PUT requests to the Autobrr API
-func (c *Client) doPut(endpoint string, body io.Reader, contentType string) ([]byte, error) {
-	return c.doRequest("PUT", endpoint, body, contentType)
+func (c *Client) doPut(op, endpoint string, body io.Reader, contentType string) ([]byte, error) {
+	return c.doRequest(op, "PUT", endpoint, body, contentType)
+}
+
+// doPatch is a helper method for making PATCH requests to the Autobrr API
+func (c *Client) doPatch(op, endpoint string, body io.Reader, contentType string) ([]byte, error) {
+	return c.doRequest(op, "PATCH", endpoint, body, contentType)
 }
 
 // doDelete is a helper method for making DELETE requests to the Autobrr API
-func (c *Client) doDelete(endpoint string) ([]byte, error) {
-	return c.doRequest("DELETE", endpoint, nil, "")
+func (c *Client) doDelete(op, endpoint string) ([]byte, error) {
+	return c.doRequest(op, "DELETE", endpoint, nil, "")
+}
+
+// doRequest is a helper function to handle HTTP requests.
+// op names the API operation, e.g. "GetFilters", for middleware and metrics.
+func (c *Client) doRequest(op, method, endpoint string, body io.Reader, contentType string) ([]byte, error) {
+	resp, err := c.send(op, method, endpoint, body, contentType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
 }
 
-// doRequest is a helper function to handle HTTP requests
-func (c *Client) doRequest(method, endpoint string, body io.Reader, contentType string) ([]byte, error) {
+// newRequest builds an authenticated API request. endpoint may include a query string.
+func (c *Client) newRequest(ctx context.Context, op, method, endpoint string, body io.Reader, contentType string, header http.Header) (*http.Request, error) {
 	apiURL, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base URL: %v", err)
 	}
 
-	apiURL.Path = endpoint
+	ref, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %v", err)
+	}
+
+	// RawPath keeps escaped segments such as %2F in file names intact
+	apiURL.Path = ref.Path
+	apiURL.RawPath = ref.RawPath
+	apiURL.RawQuery = ref.RawQuery
 
-	req, err := http.NewRequest(method, apiURL.String(), body)
+	req, err := http.NewRequestWithContext(withOperation(ctx, op), method, apiURL.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
-	// Set API key header
-	req.Header.Set("X-API-Token", c.apiKey)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	// Set API key header, unless the client authenticates with a session only
+	if apiKey := c.APIKey(); apiKey != "" {
+		req.Header.Set("X-API-Token", apiKey)
+	}
+	c.session.addCookies(req)
 
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	resp, err := c.client.Do(req)
+	return req, nil
+}
+
+// apiResponse is a fully read API response
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// openResponse sends a GET request and returns the response with its body unread,
+// for responses that are streamed instead of buffered. Non-2xx responses are
+// returned as errors. With session authentication, a 401 response logs in again
+// and the request is retried once.
+func (c *Client) openResponse(ctx context.Context, op, endpoint string, header http.Header) (*http.Response, error) {
+	resp, err := c.do(ctx, op, endpoint, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && c.canReauthenticate(op) {
+		resp.Body.Close()
+		if err := c.reauthenticate(); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, op, endpoint, header); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &statusError{StatusCode: resp.StatusCode, Body: data}
+	}
+
+	return resp, nil
+}
+
+// do sends a GET request and keeps any session cookie it sets
+func (c *Client) do(ctx context.Context, op, endpoint string, header http.Header) (*http.Response, error) {
+	req, err := c.newRequest(ctx, op, "GET", endpoint, nil, "", header)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	c.session.storeCookies(req.URL, resp.Cookies())
+
+	return resp, nil
+}
+
+// statusError reports a non-2xx response
+type statusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected response code: %d, response: %s", e.StatusCode, string(e.Body))
+}
+
+// send performs a request with the given extra headers and reads the response.
+// Non-2xx responses are returned as errors, except 304 Not Modified for
+// conditional requests carrying If-None-Match. With session authentication,
+// a 401 response logs in again and the request is retried once.
+func (c *Client) send(op, method, endpoint string, body io.Reader, contentType string, header http.Header) (*apiResponse, error) {
+	if !c.canReauthenticate(op) {
+		resp, err := c.roundTrip(op, method, endpoint, body, contentType, header)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	// Buffer the body so the request can be replayed after logging in again
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+	}
+
+	resp, err := c.roundTrip(op, method, endpoint, bytes.NewReader(payload), contentType, header)
+	if err != nil && resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		if err := c.reauthenticate(); err != nil {
+			return nil, err
+		}
+		resp, err = c.roundTrip(op, method, endpoint, bytes.NewReader(payload), contentType, header)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// roundTrip performs a single request and reads the response. For non-2xx
+// responses both the response and an error are returned.
+func (c *Client) roundTrip(op, method, endpoint string, body io.Reader, contentType string, header http.Header) (result *apiResponse, err error) {
+	req, err := c.newRequest(context.Background(), op, method, endpoint, body, contentType, header)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	statusCode := 0
+	if c.metrics != nil {
+		defer func() {
+			c.metrics.ObserveRequest(op, statusCode, err, time.Since(start))
+		}()
+	}
+
+	resp, err := c.doer.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %v", err)
 	}
 	defer resp.Body.Close()
+	statusCode = resp.StatusCode
+	c.session.storeCookies(req.URL, resp.Cookies())
 
-	responseData, err := io.ReadAll(resp.Body)
+	responseData, err := io.ReadAll(c.limitBody(op, resp.Body))
 	if err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to read response: %v", err)
 	}
 
+	result = &apiResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       responseData,
+	}
+
+	notModified := resp.StatusCode == http.StatusNotModified && req.Header.Get("If-None-Match") != ""
+
 	// Check for success status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected response code: %d, response: %s", resp.StatusCode, string(responseData))
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !notModified {
+		return result, &statusError{StatusCode: resp.StatusCode, Body: responseData}
 	}
 
-	return responseData, nil
+	return result, nil
 }
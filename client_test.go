@@ -304,15 +304,16 @@ func TestToggleFilterEnabled(t *testing.T) {
 }
 
 func TestTestConnection(t *testing.T) {
-	mockResp := FilterListResponse{Data: []Filter{}}
-	responseBody, _ := json.Marshal(mockResp)
-
 	// Mock successful response
 	endpointResponses := map[string]mockResponse{
-		"/api/filters": {statusCode: http.StatusOK, responseBody: string(responseBody)},
+		"/api/healthz/liveness":  {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/healthz/readiness": {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/config":            {statusCode: http.StatusOK, responseBody: `{"version":"v1.45.0"}`},
 	}
 	expectedRequests := []expectedRequest{
-		{method: "GET", url: "/api/filters"},
+		{method: "GET", url: "/api/healthz/liveness"},
+		{method: "GET", url: "/api/healthz/readiness"},
+		{method: "GET", url: "/api/config"},
 	}
 
 	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
@@ -334,10 +335,14 @@ func TestTestConnection(t *testing.T) {
 func TestTestConnection_Error(t *testing.T) {
 	// Mock error response
 	endpointResponses := map[string]mockResponse{
-		"/api/filters": {statusCode: http.StatusUnauthorized, responseBody: "Invalid API key"},
+		"/api/healthz/liveness":  {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/healthz/readiness": {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/config":            {statusCode: http.StatusUnauthorized, responseBody: "Invalid API key"},
 	}
 	expectedRequests := []expectedRequest{
-		{method: "GET", url: "/api/filters"},
+		{method: "GET", url: "/api/healthz/liveness"},
+		{method: "GET", url: "/api/healthz/readiness"},
+		{method: "GET", url: "/api/config"},
 	}
 
 	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
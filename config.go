@@ -0,0 +1,102 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Config represents the application config reported by the server
+type Config struct {
+	Host            string `json:"host"`
+	Port            int    `json:"port"`
+	BaseURL         string `json:"base_url"`
+	LogLevel        string `json:"log_level"` // TRACE, DEBUG, INFO, WARN or ERROR
+	LogPath         string `json:"log_path"`
+	LogMaxSize      int    `json:"log_max_size"` // megabytes
+	LogMaxBackups   int    `json:"log_max_backups"`
+	CheckForUpdates bool   `json:"check_for_updates"`
+	Version         string `json:"version"`
+	Commit          string `json:"commit"`
+	Date            string `json:"date"`
+}
+
+// ConfigUpdate is a partial config update. Only non-nil fields are changed.
+type ConfigUpdate struct {
+	LogLevel        *string `json:"log_level,omitempty"`
+	LogPath         *string `json:"log_path,omitempty"`
+	LogMaxSize      *int    `json:"log_max_size,omitempty"`
+	LogMaxBackups   *int    `json:"log_max_backups,omitempty"`
+	CheckForUpdates *bool   `json:"check_for_updates,omitempty"`
+}
+
+// GetConfig retrieves the application config
+func (c *Client) GetConfig() (*Config, error) {
+	var config Config
+	if err := c.doGetJSON("GetConfig", "/api/config", &config); err != nil {
+		return nil, fmt.Errorf("get config error: %w", err)
+	}
+
+	c.setServerVersion(config.Version)
+
+	return &config, nil
+}
+
+// UpdateConfig applies a partial update to the application config.
+// Log level changes take effect immediately; others may need a restart.
+func (c *Client) UpdateConfig(update ConfigUpdate) error {
+	jsonData, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config update: %v", err)
+	}
+
+	_, err = c.doPatch("UpdateConfig", "/api/config", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("update config error: %w", err)
+	}
+
+	return nil
+}
+
+// versionRetryInterval is how long a failed or empty version discovery is
+// reused before the config is requested again
+const versionRetryInterval = time.Minute
+
+// ServerVersion returns the version reported by the server, e.g. "v1.45.0" or
+// "dev" for development builds. The version is fetched from the config once and
+// cached for the lifetime of the client. A failed lookup, or a config without a
+// version, is cached for versionRetryInterval.
+func (c *Client) ServerVersion() (string, error) {
+	c.versionMu.Lock()
+	version, retryAt, versionErr := c.serverVersion, c.versionRetryAt, c.versionErr
+	c.versionMu.Unlock()
+	if version != "" {
+		return version, nil
+	}
+	if time.Now().Before(retryAt) {
+		return "", versionErr
+	}
+
+	config, err := c.GetConfig()
+
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if err != nil || config.Version == "" {
+		c.versionRetryAt = time.Now().Add(versionRetryInterval)
+		c.versionErr = err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return config.Version, nil
+}
+
+// setServerVersion caches the version reported by the server
+func (c *Client) setServerVersion(version string) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	c.serverVersion = version
+}
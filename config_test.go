@@ -0,0 +1,135 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const testConfigResponse = `{"host":"0.0.0.0","port":7474,"base_url":"/autobrr/","log_level":"INFO","log_path":"/config/logs/autobrr.log","log_max_size":50,"log_max_backups":3,"check_for_updates":true,"version":"v1.45.0","commit":"abc123","date":"2024-05-01T10:00:00Z"}`
+
+func TestGetConfig(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusOK, responseBody: testConfigResponse},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	config, err := client.GetConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Config{
+		Host:            "0.0.0.0",
+		Port:            7474,
+		BaseURL:         "/autobrr/",
+		LogLevel:        "INFO",
+		LogPath:         "/config/logs/autobrr.log",
+		LogMaxSize:      50,
+		LogMaxBackups:   3,
+		CheckForUpdates: true,
+		Version:         "v1.45.0",
+		Commit:          "abc123",
+		Date:            "2024-05-01T10:00:00Z",
+	}
+	if *config != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *config)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "PATCH", url: "/api/config"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/config": func(req *http.Request) {
+			var data map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			// Only the fields that were set may be sent
+			if len(data) != 2 || data["log_level"] != "DEBUG" || data["check_for_updates"] != false {
+				t.Errorf("Unexpected request body: %v", data)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	level := "DEBUG"
+	checkForUpdates := false
+	if err := client.UpdateConfig(ConfigUpdate{LogLevel: &level, CheckForUpdates: &checkForUpdates}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestServerVersion_Cached(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusOK, responseBody: testConfigResponse},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		version, err := client.ServerVersion()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if version != "v1.45.0" {
+			t.Errorf("Expected version v1.45.0, got %q", version)
+		}
+	}
+
+	// The second call must be served from the cache
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestServerVersion_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/config": {statusCode: http.StatusUnauthorized, responseBody: "unauthorized"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.ServerVersion(); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
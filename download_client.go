@@ -0,0 +1,28 @@
+package autobrr
+
+import "fmt"
+
+// DownloadClient represents a download client such as qBittorrent or Sonarr
+type DownloadClient struct {
+	ID            int                    `json:"id"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Enabled       bool                   `json:"enabled"`
+	Host          string                 `json:"host"`
+	Port          int                    `json:"port"`
+	TLS           bool                   `json:"tls"`
+	TLSSkipVerify bool                   `json:"tls_skip_verify"`
+	Username      string                 `json:"username,omitempty"`
+	Password      string                 `json:"password,omitempty"`
+	Settings      map[string]interface{} `json:"settings,omitempty"`
+}
+
+// GetDownloadClients retrieves all download clients configured on the instance
+func (c *Client) GetDownloadClients() ([]DownloadClient, error) {
+	var clients []DownloadClient
+	if err := c.doGetJSON("GetDownloadClients", "/api/download_clients", &clients); err != nil {
+		return nil, fmt.Errorf("get download clients error: %w", err)
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,38 @@
+package autobrr
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetDownloadClients(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/download_clients": {statusCode: http.StatusOK, responseBody: `[{"id":3,"name":"qbit","type":"QBITTORRENT","enabled":true,"host":"localhost","port":8080}]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/download_clients"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	clients, err := client.GetDownloadClients()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(clients) != 1 {
+		t.Fatalf("Expected 1 download client, got %d", len(clients))
+	}
+
+	if clients[0].Name != "qbit" || clients[0].Port != 8080 {
+		t.Errorf("Unexpected download client: %+v", clients[0])
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
@@ -0,0 +1,149 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// filterExportVersion is the version written by the autobrr UI when exporting filters
+const filterExportVersion = "1.0"
+
+// filterExportOmit lists filter fields that are instance-specific or computed by the
+// server and therefore left out of exports, matching the autobrr UI
+var filterExportOmit = []string{
+	"id",
+	"name",
+	"created_at",
+	"updated_at",
+	"indexer_ids",
+	"indexers",
+	"actions",
+	"actions_count",
+	"actions_enabled_count",
+	"external",
+	"downloads",
+	"is_auto_updated",
+	"release_profile_duplicate",
+}
+
+// filterExport is the envelope used by the autobrr UI filter export and import
+type filterExport struct {
+	Name    string          `json:"name"`
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// ExportFilter encodes a filter in the JSON format produced by the autobrr UI "Export JSON" action.
+// IDs, timestamps, statistics and relations to indexers, actions and external filters are stripped.
+func ExportFilter(filter *Filter) ([]byte, error) {
+	raw, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filter: %v", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to convert filter: %v", err)
+	}
+
+	for _, key := range filterExportOmit {
+		delete(data, key)
+	}
+
+	// The UI also drops these when they hold their default value
+	for _, key := range []string{"enabled", "smart_episode"} {
+		if v, ok := data[key].(bool); ok && !v {
+			delete(data, key)
+		}
+	}
+	if v, ok := data["priority"].(float64); ok && v == 0 {
+		delete(data, "priority")
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filter data: %v", err)
+	}
+
+	out, err := json.MarshalIndent(filterExport{
+		Name:    filter.Name,
+		Version: filterExportVersion,
+		Data:    dataJSON,
+	}, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filter export: %v", err)
+	}
+
+	return out, nil
+}
+
+// ExportFilterDiscord encodes a filter like ExportFilter, wrapped in a Markdown code
+// block as produced by the autobrr UI "Export JSON to Discord" action
+func ExportFilterDiscord(filter *Filter) ([]byte, error) {
+	out, err := ExportFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("```JSON\n")
+	buf.Write(out)
+	buf.WriteString("\n```")
+
+	return buf.Bytes(), nil
+}
+
+// ImportFilter decodes a filter exported by the autobrr UI or by ExportFilter.
+// The Discord variant wrapped in a Markdown code block is accepted as well.
+// The returned filter has no ID and can be passed straight to CreateFilter.
+func ImportFilter(data []byte) (*Filter, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("```")) {
+		data = bytes.TrimPrefix(data, []byte("```"))
+		// Drop the info string, e.g. "json" or "JSON", with the rest of the fence line
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		} else if i := bytes.IndexByte(data, '{'); i >= 0 {
+			data = data[i:]
+		}
+		data = bytes.TrimSuffix(bytes.TrimSpace(data), []byte("```"))
+	}
+
+	var export filterExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode filter export: %v", err)
+	}
+
+	if len(export.Data) == 0 {
+		return nil, fmt.Errorf("invalid filter export: missing data")
+	}
+
+	var filter Filter
+	if err := json.Unmarshal(export.Data, &filter); err != nil {
+		return nil, fmt.Errorf("failed to decode filter data: %v", err)
+	}
+
+	if export.Name != "" {
+		filter.Name = export.Name
+	}
+	if filter.Name == "" {
+		return nil, fmt.Errorf("invalid filter export: missing name")
+	}
+
+	// Exports from other tools may still carry instance-specific fields
+	filter.ID = 0
+	filter.CreatedAt = ""
+	filter.UpdatedAt = ""
+	filter.IndexerIDs = nil
+	filter.Indexers = nil
+	filter.Actions = nil
+	filter.ActionsCount = 0
+	filter.ActionsEnabledCount = 0
+	filter.External = nil
+	filter.Downloads = nil
+	filter.IsAutoUpdated = false
+	filter.ReleaseProfileDuplicate = nil
+
+	return &filter, nil
+}
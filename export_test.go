@@ -0,0 +1,147 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportFilter(t *testing.T) {
+	filter := &Filter{
+		ID:          12,
+		Name:        "Movies 2160p",
+		Enabled:     true,
+		CreatedAt:   "2024-01-01T00:00:00Z",
+		UpdatedAt:   "2024-02-01T00:00:00Z",
+		Resolutions: []string{"2160p"},
+		IndexerIDs:  []int{1},
+		Indexers:    []Indexer{{ID: 1, Identifier: "ptp"}},
+		Actions:     []Action{{ID: 3, Name: "qbit", ClientID: 2}},
+		Downloads:   &Downloads{TotalCount: 10},
+	}
+
+	out, err := ExportFilter(filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var export struct {
+		Name    string                 `json:"name"`
+		Version string                 `json:"version"`
+		Data    map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &export); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+
+	if export.Name != "Movies 2160p" || export.Version != "1.0" {
+		t.Errorf("Unexpected envelope: name=%q version=%q", export.Name, export.Version)
+	}
+
+	for _, key := range []string{"id", "created_at", "updated_at", "indexer_ids", "indexers", "actions", "downloads", "priority", "smart_episode"} {
+		if _, ok := export.Data[key]; ok {
+			t.Errorf("Expected %q to be stripped from export", key)
+		}
+	}
+
+	if enabled, ok := export.Data["enabled"].(bool); !ok || !enabled {
+		t.Errorf("Expected enabled to be kept, got %v", export.Data["enabled"])
+	}
+
+	if !strings.Contains(string(out), "\n    \"data\"") {
+		t.Errorf("Expected export to be indented with four spaces, got %s", out)
+	}
+
+	discord, err := ExportFilterDiscord(filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := "```JSON\n" + string(out) + "\n```"; string(discord) != expected {
+		t.Errorf("Expected the Discord export to be %q, got %q", expected, discord)
+	}
+}
+
+func TestImportFilter_RoundTrip(t *testing.T) {
+	original := &Filter{
+		ID:             4,
+		Name:           "TV",
+		Priority:       10,
+		Shows:          "Show A,Show B",
+		Resolutions:    []string{"1080p"},
+		MatchLanguage:  []string{"english"},
+		Freeleech:      true,
+		MaxDownloads:   5,
+		MatchUploaders: "uploader",
+	}
+
+	for _, export := range []func(*Filter) ([]byte, error){ExportFilter, ExportFilterDiscord} {
+		out, err := export(original)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		imported, err := ImportFilter(out)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if imported.ID != 0 {
+			t.Errorf("Expected imported filter to have no ID, got %d", imported.ID)
+		}
+		if imported.Name != "TV" || imported.Priority != 10 || imported.Shows != original.Shows || !imported.Freeleech || imported.MaxDownloads != 5 {
+			t.Errorf("Imported filter does not match original: %+v", imported)
+		}
+		if len(imported.Resolutions) != 1 || imported.Resolutions[0] != "1080p" {
+			t.Errorf("Expected resolutions [1080p], got %v", imported.Resolutions)
+		}
+	}
+}
+
+func TestImportFilter_CommunityFormat(t *testing.T) {
+	// As pasted from Discord, including the id and indexers some older exports carry
+	input := "```json\n{\n    \"name\": \"Freeleech\",\n    \"version\": \"1.0\",\n    \"data\": {\n        \"id\": 99,\n        \"freeleech\": true,\n        \"max_size\": \"20GB\",\n        \"indexers\": [{\"id\": 1}]\n    }\n}\n```\n"
+
+	filter, err := ImportFilter([]byte(input))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if filter.Name != "Freeleech" || !filter.Freeleech || filter.MaxSize != "20GB" {
+		t.Errorf("Unexpected filter: %+v", filter)
+	}
+	if filter.ID != 0 || filter.Indexers != nil {
+		t.Errorf("Expected instance-specific fields to be stripped, got id=%d indexers=%v", filter.ID, filter.Indexers)
+	}
+}
+
+func TestImportFilter_FenceInfoString(t *testing.T) {
+	body := `{"name": "Freeleech", "version": "1.0", "data": {"freeleech": true}}`
+
+	for _, input := range []string{
+		"```JSON\n" + body + "\n```",
+		"```jsonc \r\n" + body + "\r\n```",
+		"```\n" + body + "\n```",
+		"```" + body + "```",
+	} {
+		filter, err := ImportFilter([]byte(input))
+		if err != nil {
+			t.Errorf("Expected no error for input %q, got %v", input, err)
+			continue
+		}
+		if filter.Name != "Freeleech" || !filter.Freeleech {
+			t.Errorf("Unexpected filter for input %q: %+v", input, filter)
+		}
+	}
+}
+
+func TestImportFilter_Invalid(t *testing.T) {
+	for _, input := range []string{
+		`not json`,
+		`{"name": "no data", "version": "1.0"}`,
+		`{"version": "1.0", "data": {"enabled": true}}`,
+	} {
+		if _, err := ImportFilter([]byte(input)); err == nil {
+			t.Errorf("Expected error for input %q, got none", input)
+		}
+	}
+}
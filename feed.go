@@ -0,0 +1,165 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// FeedType is the kind of feed polled by autobrr
+type FeedType string
+
+const (
+	FeedTypeTorznab FeedType = "TORZNAB"
+	FeedTypeNewznab FeedType = "NEWZNAB"
+	FeedTypeRSS     FeedType = "RSS"
+)
+
+// Feed represents a Torznab, Newznab or RSS feed polled by autobrr
+type Feed struct {
+	ID   int      `json:"id,omitempty"`
+	Name string   `json:"name"`
+	Type FeedType `json:"type"`
+	// Indexer is the indexer the feed belongs to. Older autobrr versions report only its identifier.
+	Indexer      ReleaseIndexer `json:"indexer"`
+	IndexerID    int            `json:"indexer_id,omitempty"`
+	Enabled      bool           `json:"enabled"`
+	URL          string         `json:"url"`
+	APIKey       string         `json:"api_key,omitempty"`
+	Cookie       string         `json:"cookie,omitempty"`
+	Interval     int            `json:"interval"` // minutes
+	Timeout      int            `json:"timeout"`  // seconds
+	MaxAge       int            `json:"max_age"`  // seconds
+	Categories   []int          `json:"categories,omitempty"`
+	Capabilities []string       `json:"capabilities,omitempty"`
+	Settings     *FeedSettings  `json:"settings,omitempty"`
+	CreatedAt    string         `json:"created_at,omitempty"`
+	UpdatedAt    string         `json:"updated_at,omitempty"`
+	LastRun      string         `json:"last_run,omitempty"`
+	LastRunData  string         `json:"last_run_data,omitempty"`
+	NextRun      string         `json:"next_run,omitempty"`
+}
+
+// FeedSettings holds type-specific feed settings
+type FeedSettings struct {
+	// DownloadType is "TORRENT" or "MAGNET" for RSS feeds
+	DownloadType string `json:"download_type,omitempty"`
+}
+
+// GetFeeds retrieves all feeds
+func (c *Client) GetFeeds() ([]Feed, error) {
+	var feeds []Feed
+	if err := c.doGetJSON("GetFeeds", "/api/feeds", &feeds); err != nil {
+		return nil, fmt.Errorf("get feeds error: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// CreateFeed creates a new feed
+func (c *Client) CreateFeed(feed *Feed) (*Feed, error) {
+	jsonData, err := json.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feed: %v", err)
+	}
+
+	respData, err := c.doPost("CreateFeed", "/api/feeds", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("create feed error: %w", err)
+	}
+
+	createdFeed := *feed
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &createdFeed); err != nil {
+			return nil, fmt.Errorf("failed to decode created feed: %v", err)
+		}
+	}
+
+	return &createdFeed, nil
+}
+
+// UpdateFeed updates an existing feed.
+// When the server does not echo the feed back, the submitted feed is returned.
+func (c *Client) UpdateFeed(id int64, feed *Feed) (*Feed, error) {
+	jsonData, err := json.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feed: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("/api/feeds/%d", id)
+	respData, err := c.doPut("UpdateFeed", endpoint, bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("update feed error: %w", err)
+	}
+
+	updatedFeed := *feed
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &updatedFeed); err != nil {
+			return nil, fmt.Errorf("failed to decode updated feed: %v", err)
+		}
+	}
+
+	return &updatedFeed, nil
+}
+
+// DeleteFeed deletes a feed by ID
+func (c *Client) DeleteFeed(id int64) error {
+	endpoint := fmt.Sprintf("/api/feeds/%d", id)
+	_, err := c.doDelete("DeleteFeed", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete feed error: %w", err)
+	}
+
+	return nil
+}
+
+// ToggleFeedEnabled enables or disables a feed
+func (c *Client) ToggleFeedEnabled(id int64, enabled bool) error {
+	endpoint := fmt.Sprintf("/api/feeds/%d/enabled", id)
+	data := map[string]bool{"enabled": enabled}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal toggle data: %v", err)
+	}
+
+	_, err = c.doPatch("ToggleFeedEnabled", endpoint, bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("toggle feed error: %w", err)
+	}
+
+	return nil
+}
+
+// ForceRunFeed refreshes a feed immediately instead of waiting for its interval
+func (c *Client) ForceRunFeed(id int64) error {
+	endpoint := fmt.Sprintf("/api/feeds/%d/forcerun", id)
+	_, err := c.doPost("ForceRunFeed", endpoint, nil, "")
+	if err != nil {
+		return fmt.Errorf("force run feed error: %w", err)
+	}
+
+	return nil
+}
+
+// ClearFeedCache clears the cache of seen items for a feed, so items are processed again on the next run
+func (c *Client) ClearFeedCache(id int64) error {
+	endpoint := fmt.Sprintf("/api/feeds/%d/cache", id)
+	_, err := c.doDelete("ClearFeedCache", endpoint)
+	if err != nil {
+		return fmt.Errorf("clear feed cache error: %w", err)
+	}
+
+	return nil
+}
+
+// GetFeedLatestRun retrieves the raw output of a feed's most recent run
+func (c *Client) GetFeedLatestRun(id int64) (string, error) {
+	endpoint := fmt.Sprintf("/api/feeds/%d/latest", id)
+	respData, err := c.doGet("GetFeedLatestRun", endpoint)
+	if err != nil {
+		return "", fmt.Errorf("get feed latest run error: %w", err)
+	}
+
+	return string(respData), nil
+}
@@ -0,0 +1,206 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetFeeds(t *testing.T) {
+	// The first feed uses the indexer object of current versions, the second the plain identifier of older ones
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds": {statusCode: http.StatusOK, responseBody: `[
+			{"id":1,"name":"MyIndexer","type":"TORZNAB","indexer":{"id":3,"name":"MyIndexer","identifier":"torznab-myindexer"},"enabled":true,"url":"http://prowlarr:9696/1/api","interval":15,"timeout":60,"max_age":3600,"capabilities":["search"],"last_run":"2024-05-01T10:00:00Z","next_run":"2024-05-01T10:15:00Z"},
+			{"id":2,"name":"RSS","type":"RSS","indexer":"rss-site","enabled":false,"url":"https://example.com/rss","interval":30,"settings":{"download_type":"MAGNET"}}
+		]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/feeds"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	feeds, err := client.GetFeeds()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(feeds) != 2 {
+		t.Fatalf("Expected 2 feeds, got %d", len(feeds))
+	}
+	if feeds[0].Type != FeedTypeTorznab || feeds[0].Indexer.ID != 3 || feeds[0].MaxAge != 3600 || feeds[0].NextRun != "2024-05-01T10:15:00Z" {
+		t.Errorf("Unexpected first feed: %+v", feeds[0])
+	}
+	if feeds[1].Indexer.Identifier != "rss-site" || feeds[1].Settings == nil || feeds[1].Settings.DownloadType != "MAGNET" {
+		t.Errorf("Unexpected second feed: %+v", feeds[1])
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestCreateFeed(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds": {statusCode: http.StatusCreated, responseBody: `{"id":5,"name":"MyIndexer","type":"TORZNAB","indexer_id":3,"enabled":true}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/feeds"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/feeds": func(req *http.Request) {
+			var feed Feed
+			if err := json.NewDecoder(req.Body).Decode(&feed); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if feed.Name != "MyIndexer" || feed.IndexerID != 3 || feed.APIKey != "secret" {
+				t.Errorf("Unexpected request body: %+v", feed)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	feed, err := client.CreateFeed(&Feed{Name: "MyIndexer", Type: FeedTypeTorznab, IndexerID: 3, Enabled: true, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if feed.ID != 5 {
+		t.Errorf("Expected feed ID 5, got %d", feed.ID)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestUpdateFeed_NoContent(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds/5": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "PUT", url: "/api/feeds/5"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	feed, err := client.UpdateFeed(5, &Feed{ID: 5, Name: "Renamed", Interval: 30})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if feed.Name != "Renamed" || feed.Interval != 30 {
+		t.Errorf("Expected submitted feed to be returned, got %+v", feed)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestToggleFeedEnabled(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds/5/enabled": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "PATCH", url: "/api/feeds/5/enabled"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/feeds/5/enabled": func(req *http.Request) {
+			var data map[string]bool
+			if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if enabled, ok := data["enabled"]; !ok || enabled {
+				t.Errorf("Expected enabled: false, got %v", data)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.ToggleFeedEnabled(5, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestFeedMaintenance(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds/5/forcerun": {statusCode: http.StatusNoContent},
+		"/api/feeds/5/cache":    {statusCode: http.StatusNoContent},
+		"/api/feeds/5/latest":   {statusCode: http.StatusOK, responseBody: "<rss>...</rss>"},
+		"/api/feeds/5":          {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/feeds/5/forcerun"},
+		{method: "DELETE", url: "/api/feeds/5/cache"},
+		{method: "GET", url: "/api/feeds/5/latest"},
+		{method: "DELETE", url: "/api/feeds/5"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.ForceRunFeed(5); err != nil {
+		t.Fatalf("Expected no error from ForceRunFeed, got %v", err)
+	}
+	if err := client.ClearFeedCache(5); err != nil {
+		t.Fatalf("Expected no error from ClearFeedCache, got %v", err)
+	}
+	output, err := client.GetFeedLatestRun(5)
+	if err != nil {
+		t.Fatalf("Expected no error from GetFeedLatestRun, got %v", err)
+	}
+	if output != "<rss>...</rss>" {
+		t.Errorf("Unexpected latest run output: %q", output)
+	}
+	if err := client.DeleteFeed(5); err != nil {
+		t.Fatalf("Expected no error from DeleteFeed, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestGetFeeds_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/feeds": {statusCode: http.StatusInternalServerError, responseBody: "internal error"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/feeds"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.GetFeeds(); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
@@ -0,0 +1,95 @@
+package autobrr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HealthReport is the result of the checks run by Health
+type HealthReport struct {
+	// Reachable is true when the liveness endpoint answered
+	Reachable bool
+	// DatabaseOK is true when the readiness endpoint, which checks the database, answered
+	DatabaseOK bool
+	// Authenticated is true when the client's credentials were accepted
+	Authenticated bool
+	// Unauthorized is true when the server rejected the client's credentials
+	// with 401 or 403, as opposed to the check failing for another reason
+	Unauthorized bool
+	// Latency is the round trip time of the liveness check
+	Latency time.Duration
+	// ServerVersion is the version reported by the server, when authenticated
+	ServerVersion string
+}
+
+// Health checks the server without transferring any data set. It calls the
+// liveness and readiness endpoints and then the config endpoint as a
+// lightweight authenticated call. The report is always returned; the error
+// describes every failed check.
+func (c *Client) Health() (*HealthReport, error) {
+	report := &HealthReport{}
+
+	start := time.Now()
+	if err := c.checkHealthz(context.Background(), "Liveness", "/api/healthz/liveness"); err != nil {
+		return report, fmt.Errorf("server unreachable: %v", err)
+	}
+	report.Reachable = true
+	report.Latency = time.Since(start)
+
+	var failures []string
+
+	if err := c.checkHealthz(context.Background(), "Readiness", "/api/healthz/readiness"); err != nil {
+		failures = append(failures, fmt.Sprintf("server not ready: %v", err))
+	} else {
+		report.DatabaseOK = true
+	}
+
+	resp, err := c.send("Health", "GET", "/api/config", nil, "", nil)
+	var statusErr *statusError
+	switch {
+	case err == nil:
+		report.Authenticated = true
+
+		var config Config
+		if err := json.Unmarshal(resp.Body, &config); err == nil && config.Version != "" {
+			report.ServerVersion = config.Version
+			c.setServerVersion(config.Version)
+		}
+	case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden):
+		report.Unauthorized = true
+		failures = append(failures, fmt.Sprintf("not authenticated: %v", err))
+	default:
+		failures = append(failures, fmt.Sprintf("authenticated check failed: %v", err))
+	}
+
+	if len(failures) > 0 {
+		return report, errors.New(strings.Join(failures, "; "))
+	}
+
+	return report, nil
+}
+
+// checkHealthz calls an unauthenticated health endpoint, which answers 200 when healthy
+func (c *Client) checkHealthz(ctx context.Context, op, endpoint string) error {
+	req, err := c.newRequest(ctx, op, "GET", endpoint, nil, "", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s check failed with response code: %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
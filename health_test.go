@@ -0,0 +1,104 @@
+package autobrr
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/healthz/liveness":  {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/healthz/readiness": {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/config":            {statusCode: http.StatusOK, responseBody: `{"version":"v1.45.0"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/healthz/liveness"},
+		{method: "GET", url: "/api/healthz/readiness"},
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := client.Health()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !report.Reachable || !report.DatabaseOK || !report.Authenticated || report.Unauthorized || report.ServerVersion != "v1.45.0" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if report.Latency <= 0 {
+		t.Errorf("Expected latency to be measured, got %v", report.Latency)
+	}
+
+	// The reported version is cached for capability checks
+	if version, _ := client.ServerVersion(); version != "v1.45.0" {
+		t.Errorf("Expected cached version v1.45.0, got %q", version)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestHealth_DatabaseDownAndBadKey(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/healthz/liveness":  {statusCode: http.StatusOK, responseBody: "OK"},
+		"/api/healthz/readiness": {statusCode: http.StatusInternalServerError, responseBody: "database unavailable"},
+		"/api/config":            {statusCode: http.StatusUnauthorized, responseBody: "unauthorized"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/healthz/liveness"},
+		{method: "GET", url: "/api/healthz/readiness"},
+		{method: "GET", url: "/api/config"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := client.Health()
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if !report.Reachable || report.DatabaseOK || report.Authenticated || !report.Unauthorized {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if !strings.Contains(err.Error(), "not ready") || !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("Expected both failures to be reported, got %v", err)
+	}
+}
+
+func TestHealth_Unreachable(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/healthz/liveness": {statusCode: http.StatusBadGateway, responseBody: "bad gateway"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/healthz/liveness"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := client.Health()
+	if err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("Expected unreachable error, got %v", err)
+	}
+	if report.Reachable || report.Authenticated {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	// No further checks are made against an unreachable server
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
@@ -0,0 +1,13 @@
+package autobrr
+
+import "fmt"
+
+// GetIndexers retrieves all indexers configured on the instance
+func (c *Client) GetIndexers() ([]Indexer, error) {
+	var indexers []Indexer
+	if err := c.doGetJSON("GetIndexers", "/api/indexer", &indexers); err != nil {
+		return nil, fmt.Errorf("get indexers error: %w", err)
+	}
+
+	return indexers, nil
+}
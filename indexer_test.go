@@ -0,0 +1,41 @@
+package autobrr
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetIndexers(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/indexer": {statusCode: http.StatusOK, responseBody: `[{"id":1,"name":"BroadcasTheNet","identifier":"btn","enabled":true,"use_proxy":true,"proxy_id":2,"proxy":{"id":2,"name":"seedbox","enabled":true,"type":"SOCKS5","addr":"socks5://10.0.0.2:1080"},"settings":{"authkey":"secret"}}]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/indexer"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	indexers, err := client.GetIndexers()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(indexers) != 1 {
+		t.Fatalf("Expected 1 indexer, got %d", len(indexers))
+	}
+
+	if indexers[0].Identifier != "btn" {
+		t.Errorf("Expected identifier 'btn', got '%s'", indexers[0].Identifier)
+	}
+	if proxy := indexers[0].Proxy; proxy == nil || proxy.Type != ProxyTypeSOCKS5 || proxy.Name != "seedbox" {
+		t.Errorf("Unexpected proxy: %+v", indexers[0].Proxy)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
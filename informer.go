@@ -0,0 +1,256 @@
+package autobrr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FilterEventType is the kind of change detected by a FilterInformer
+type FilterEventType string
+
+const (
+	FilterAdded   FilterEventType = "added"
+	FilterUpdated FilterEventType = "updated"
+	FilterDeleted FilterEventType = "deleted"
+	// FilterToggled is emitted instead of FilterUpdated when only Enabled changed
+	FilterToggled FilterEventType = "toggled"
+)
+
+// FilterEvent describes a change to a filter between two syncs.
+// Old is nil for FilterAdded and New is nil for FilterDeleted.
+type FilterEvent struct {
+	Type FilterEventType
+	Old  *Filter
+	New  *Filter
+}
+
+// FilterEventHandler is called for every event, in order, from the goroutine running the informer
+type FilterEventHandler func(FilterEvent)
+
+// defaultResyncPeriod is used when FilterInformerOptions.ResyncPeriod is zero
+const defaultResyncPeriod = 30 * time.Second
+
+// eventBufferSize is the capacity of the channel returned by FilterInformer.Events
+const eventBufferSize = 64
+
+// FilterInformerOptions configures a FilterInformer
+type FilterInformerOptions struct {
+	// ResyncPeriod is the interval between GetFilters calls. Zero means 30 seconds.
+	ResyncPeriod time.Duration
+
+	// OnError is called when a periodic resync fails. The store keeps its previous state.
+	OnError func(error)
+}
+
+// FilterInformer keeps a local copy of all filters by periodically calling
+// GetFilters and emits events for filters that were added, updated, toggled or
+// deleted between syncs. Events are delivered to registered handlers and, once
+// Events has been called, on a channel.
+//
+// The initial sync emits FilterAdded for every existing filter.
+// Changes to actions are not detected since the filter list does not include them.
+type FilterInformer struct {
+	api  API
+	opts FilterInformerOptions
+
+	// syncMu serialises syncs and closing the event channel
+	syncMu sync.Mutex
+
+	mu       sync.RWMutex
+	store    map[int]Filter
+	synced   bool
+	handlers []FilterEventHandler
+	events   chan FilterEvent
+}
+
+// NewFilterInformer creates an informer watching the filters of api. Call Run to start it.
+func NewFilterInformer(api API, opts FilterInformerOptions) *FilterInformer {
+	if opts.ResyncPeriod <= 0 {
+		opts.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return &FilterInformer{
+		api:   api,
+		opts:  opts,
+		store: make(map[int]Filter),
+	}
+}
+
+// AddHandler registers a handler for all subsequent events
+func (i *FilterInformer) AddHandler(handler FilterEventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.handlers = append(i.handlers, handler)
+}
+
+// Events returns a channel receiving all subsequent events. It is closed when Run returns.
+// The informer blocks while the channel is full, so it must be drained.
+func (i *FilterInformer) Events() <-chan FilterEvent {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.events == nil {
+		i.events = make(chan FilterEvent, eventBufferSize)
+	}
+
+	return i.events
+}
+
+// HasSynced reports whether the initial sync has completed
+func (i *FilterInformer) HasSynced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.synced
+}
+
+// List returns the filters in the local store ordered by ID
+func (i *FilterInformer) List() []Filter {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	filters := make([]Filter, 0, len(i.store))
+	for _, f := range i.store {
+		filters = append(filters, f)
+	}
+	sort.Slice(filters, func(a, b int) bool { return filters[a].ID < filters[b].ID })
+
+	return filters
+}
+
+// Get returns the filter with the given ID from the local store
+func (i *FilterInformer) Get(id int) (Filter, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	f, ok := i.store[id]
+	return f, ok
+}
+
+// Run performs the initial sync and then resyncs every ResyncPeriod until ctx is done.
+// It returns the error of a failed initial sync, or ctx.Err() when stopped.
+func (i *FilterInformer) Run(ctx context.Context) error {
+	defer func() {
+		i.syncMu.Lock()
+		defer i.syncMu.Unlock()
+
+		i.mu.Lock()
+		if i.events != nil {
+			close(i.events)
+			i.events = nil
+		}
+		i.mu.Unlock()
+	}()
+
+	if err := i.Resync(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(i.opts.ResyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := i.Resync(ctx); err != nil && ctx.Err() == nil && i.opts.OnError != nil {
+				i.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// Resync fetches the current filters, updates the store and emits events for
+// any differences. It is called by Run and can be used to force a sync.
+// ctx only bounds event delivery on the channel.
+func (i *FilterInformer) Resync(ctx context.Context) error {
+	i.syncMu.Lock()
+	defer i.syncMu.Unlock()
+
+	filters, err := i.api.GetFilters()
+	if err != nil {
+		return fmt.Errorf("filter informer sync error: %w", err)
+	}
+
+	i.mu.Lock()
+	events := diffFilters(i.store, filters)
+	i.store = make(map[int]Filter, len(filters))
+	for _, f := range filters {
+		i.store[f.ID] = f
+	}
+	i.synced = true
+	handlers := append([]FilterEventHandler(nil), i.handlers...)
+	ch := i.events
+	i.mu.Unlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+		if ch != nil {
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffFilters returns the events turning old into current, ordered by filter ID
+func diffFilters(old map[int]Filter, current []Filter) []FilterEvent {
+	var events []FilterEvent
+	seen := make(map[int]bool, len(current))
+
+	sorted := append([]Filter(nil), current...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].ID < sorted[b].ID })
+
+	for idx := range sorted {
+		f := sorted[idx]
+		seen[f.ID] = true
+
+		prev, ok := old[f.ID]
+		switch {
+		case !ok:
+			events = append(events, FilterEvent{Type: FilterAdded, New: &f})
+		case !filtersEqualIgnoringEnabled(prev, f):
+			events = append(events, FilterEvent{Type: FilterUpdated, Old: &prev, New: &f})
+		case prev.Enabled != f.Enabled:
+			events = append(events, FilterEvent{Type: FilterToggled, Old: &prev, New: &f})
+		}
+	}
+
+	var deleted []int
+	for id := range old {
+		if !seen[id] {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Ints(deleted)
+	for _, id := range deleted {
+		prev := old[id]
+		events = append(events, FilterEvent{Type: FilterDeleted, Old: &prev})
+	}
+
+	return events
+}
+
+// filtersEqualIgnoringEnabled compares two filters ignoring Enabled, as well as
+// timestamps and download statistics which change without user edits
+func filtersEqualIgnoringEnabled(a, b Filter) bool {
+	for _, f := range []*Filter{&a, &b} {
+		f.UpdatedAt = ""
+		f.Downloads = nil
+		f.Enabled = false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,167 @@
+package autobrr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeFilterLister is an API whose GetFilters returns a replaceable list
+type fakeFilterLister struct {
+	API
+	mu      sync.Mutex
+	filters []Filter
+	err     error
+}
+
+func (f *fakeFilterLister) GetFilters() ([]Filter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Filter(nil), f.filters...), f.err
+}
+
+func (f *fakeFilterLister) set(filters []Filter, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.filters, f.err = filters, err
+}
+
+func TestFilterInformer_Resync(t *testing.T) {
+	api := &fakeFilterLister{filters: []Filter{
+		{ID: 1, Name: "TV", Enabled: true},
+		{ID: 2, Name: "Movies", Enabled: true},
+		{ID: 3, Name: "Music"},
+	}}
+
+	informer := NewFilterInformer(api, FilterInformerOptions{})
+
+	var events []FilterEvent
+	informer.AddHandler(func(e FilterEvent) { events = append(events, e) })
+
+	ctx := context.Background()
+	if err := informer.Resync(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !informer.HasSynced() {
+		t.Error("Expected informer to have synced")
+	}
+	if len(events) != 3 || events[0].Type != FilterAdded || events[0].New.Name != "TV" || events[0].Old != nil {
+		t.Fatalf("Expected 3 added events, got %+v", events)
+	}
+
+	// Rename TV, disable Movies, delete Music, add Anime, and bump Movies' statistics
+	api.set([]Filter{
+		{ID: 1, Name: "TV Shows", Enabled: true},
+		{ID: 2, Name: "Movies", Enabled: false, UpdatedAt: "2024-01-02T00:00:00Z", Downloads: &Downloads{TotalCount: 5}},
+		{ID: 4, Name: "Anime"},
+	}, nil)
+	events = nil
+
+	if err := informer.Resync(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		typ FilterEventType
+		id  int
+	}{
+		{FilterUpdated, 1},
+		{FilterToggled, 2},
+		{FilterAdded, 4},
+		{FilterDeleted, 3},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), events)
+	}
+	for i, e := range expected {
+		got := events[i]
+		id := 0
+		if got.New != nil {
+			id = got.New.ID
+		} else if got.Old != nil {
+			id = got.Old.ID
+		}
+		if got.Type != e.typ || id != e.id {
+			t.Errorf("Event %d: expected %s for filter %d, got %s for filter %d", i, e.typ, e.id, got.Type, id)
+		}
+	}
+
+	if events[0].Old.Name != "TV" || events[0].New.Name != "TV Shows" {
+		t.Errorf("Expected before and after filters, got %+v -> %+v", events[0].Old, events[0].New)
+	}
+
+	if list := informer.List(); len(list) != 3 || list[2].Name != "Anime" {
+		t.Errorf("Unexpected store contents: %+v", list)
+	}
+	if _, ok := informer.Get(3); ok {
+		t.Error("Expected deleted filter to be removed from the store")
+	}
+}
+
+func TestFilterInformer_Run(t *testing.T) {
+	api := &fakeFilterLister{filters: []Filter{{ID: 1, Name: "TV"}}}
+
+	var errs []error
+	var errMu sync.Mutex
+	informer := NewFilterInformer(api, FilterInformerOptions{
+		ResyncPeriod: 10 * time.Millisecond,
+		OnError: func(err error) {
+			errMu.Lock()
+			errs = append(errs, err)
+			errMu.Unlock()
+		},
+	})
+	events := informer.Events()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- informer.Run(ctx) }()
+
+	if e := <-events; e.Type != FilterAdded || e.New.ID != 1 {
+		t.Fatalf("Expected initial added event, got %+v", e)
+	}
+
+	api.set(nil, errors.New("server down"))
+	time.Sleep(30 * time.Millisecond)
+	api.set([]Filter{{ID: 1, Name: "TV", Enabled: true}}, nil)
+
+	select {
+	case e := <-events:
+		if e.Type != FilterToggled || !e.New.Enabled {
+			t.Errorf("Expected toggled event, got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for toggled event")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected events channel to be closed")
+	}
+
+	errMu.Lock()
+	defer errMu.Unlock()
+	if len(errs) == 0 {
+		t.Error("Expected failed resyncs to be reported")
+	}
+}
+
+func TestFilterInformer_InitialSyncError(t *testing.T) {
+	api := &fakeFilterLister{err: errors.New("unauthorized")}
+	informer := NewFilterInformer(api, FilterInformerOptions{})
+
+	if err := informer.Run(context.Background()); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if informer.HasSynced() {
+		t.Error("Expected informer not to have synced")
+	}
+}
@@ -0,0 +1,178 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ListType is the source a list is synced from
+type ListType string
+
+const (
+	ListTypeSonarr     ListType = "SONARR"
+	ListTypeRadarr     ListType = "RADARR"
+	ListTypeLidarr     ListType = "LIDARR"
+	ListTypeReadarr    ListType = "READARR"
+	ListTypeWhisparr   ListType = "WHISPARR"
+	ListTypeMDBList    ListType = "MDBLIST"
+	ListTypeTrakt      ListType = "TRAKT"
+	ListTypeMetacritic ListType = "METACRITIC"
+	ListTypeSteam      ListType = "STEAM"
+	ListTypePlaintext  ListType = "PLAINTEXT"
+)
+
+// ListRefreshStatus is the outcome of a list's last refresh
+type ListRefreshStatus string
+
+const (
+	ListRefreshStatusSuccess ListRefreshStatus = "SUCCESS"
+	ListRefreshStatusError   ListRefreshStatus = "ERROR"
+)
+
+// List represents a list that keeps the titles of its filters in sync with an
+// external source. Filters updated by a list are marked IsAutoUpdated.
+type List struct {
+	ID      int      `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	Type    ListType `json:"type"`
+	Enabled bool     `json:"enabled"`
+	// ClientID is the arr download client used by the Sonarr, Radarr, Lidarr, Readarr and Whisparr types
+	ClientID int `json:"client_id,omitempty"`
+	// URL is the source of the MDBList, Trakt, Metacritic, Steam and plaintext types
+	URL                    string            `json:"url,omitempty"`
+	Headers                []string          `json:"headers,omitempty"`
+	APIKey                 string            `json:"api_key,omitempty"`
+	Filters                []ListFilter      `json:"filters"`
+	MatchRelease           bool              `json:"match_release"`
+	TagsInclude            []string          `json:"tags_included,omitempty"`
+	TagsExclude            []string          `json:"tags_excluded,omitempty"`
+	IncludeUnmonitored     bool              `json:"include_unmonitored"`
+	IncludeAlternateTitles bool              `json:"include_alternate_titles"`
+	LastRefreshTime        string            `json:"last_refresh_time,omitempty"`
+	LastRefreshError       string            `json:"last_refresh_error,omitempty"`
+	LastRefreshStatus      ListRefreshStatus `json:"last_refresh_status,omitempty"`
+	CreatedAt              string            `json:"created_at,omitempty"`
+	UpdatedAt              string            `json:"updated_at,omitempty"`
+}
+
+// ListFilter is a filter linked to a list. Only ID is required when saving a list.
+type ListFilter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// GetLists retrieves all lists
+func (c *Client) GetLists() ([]List, error) {
+	if err := c.require("GetLists", FeatureLists); err != nil {
+		return nil, err
+	}
+
+	var lists []List
+	if err := c.doGetJSON("GetLists", "/api/lists", &lists); err != nil {
+		return nil, fmt.Errorf("get lists error: %w", err)
+	}
+
+	return lists, nil
+}
+
+// CreateList creates a new list
+func (c *Client) CreateList(list *List) (*List, error) {
+	if err := c.require("CreateList", FeatureLists); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal list: %v", err)
+	}
+
+	respData, err := c.doPost("CreateList", "/api/lists", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("create list error: %w", err)
+	}
+
+	createdList := *list
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &createdList); err != nil {
+			return nil, fmt.Errorf("failed to decode created list: %v", err)
+		}
+	}
+
+	return &createdList, nil
+}
+
+// UpdateList updates an existing list.
+// When the server does not echo the list back, the submitted list is returned.
+func (c *Client) UpdateList(id int64, list *List) (*List, error) {
+	if err := c.require("UpdateList", FeatureLists); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal list: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("/api/lists/%d", id)
+	respData, err := c.doPut("UpdateList", endpoint, bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("update list error: %w", err)
+	}
+
+	updatedList := *list
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &updatedList); err != nil {
+			return nil, fmt.Errorf("failed to decode updated list: %v", err)
+		}
+	}
+
+	return &updatedList, nil
+}
+
+// DeleteList deletes a list by ID. Linked filters are kept.
+func (c *Client) DeleteList(id int64) error {
+	if err := c.require("DeleteList", FeatureLists); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/api/lists/%d", id)
+	_, err := c.doDelete("DeleteList", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete list error: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshList syncs a list's filters from its source immediately.
+// Cached filters are invalidated, since the refresh rewrites them.
+func (c *Client) RefreshList(id int64) error {
+	if err := c.require("RefreshList", FeatureLists); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/api/lists/%d/refresh", id)
+	_, err := c.doPost("RefreshList", endpoint, nil, "")
+	c.InvalidateFilterCache()
+	if err != nil {
+		return fmt.Errorf("refresh list error: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshAllLists syncs the filters of every enabled list immediately
+func (c *Client) RefreshAllLists() error {
+	if err := c.require("RefreshAllLists", FeatureLists); err != nil {
+		return err
+	}
+
+	_, err := c.doPost("RefreshAllLists", "/api/lists/refresh", nil, "")
+	c.InvalidateFilterCache()
+	if err != nil {
+		return fmt.Errorf("refresh lists error: %w", err)
+	}
+
+	return nil
+}
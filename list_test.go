@@ -0,0 +1,153 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetLists(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/lists": {statusCode: http.StatusOK, responseBody: `[
+			{"id":1,"name":"Sonarr","type":"SONARR","enabled":true,"client_id":2,"filters":[{"id":4,"name":"TV"}],"match_release":false,"tags_included":["autobrr"],"include_unmonitored":true,"last_refresh_status":"SUCCESS","last_refresh_time":"2024-05-01T10:00:00Z"},
+			{"id":2,"name":"Watchlist","type":"TRAKT","enabled":true,"url":"https://api.autobrr.com/lists/trakt/popular-tv","filters":[],"last_refresh_status":"ERROR","last_refresh_error":"timeout"}
+		]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/lists"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	lists, err := client.GetLists()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lists) != 2 {
+		t.Fatalf("Expected 2 lists, got %d", len(lists))
+	}
+	if lists[0].Type != ListTypeSonarr || lists[0].ClientID != 2 || !lists[0].IncludeUnmonitored || len(lists[0].Filters) != 1 || lists[0].Filters[0].ID != 4 {
+		t.Errorf("Unexpected first list: %+v", lists[0])
+	}
+	if lists[1].LastRefreshStatus != ListRefreshStatusError || lists[1].LastRefreshError != "timeout" {
+		t.Errorf("Unexpected second list: %+v", lists[1])
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/lists": {statusCode: http.StatusCreated, responseBody: `{"id":3,"name":"Movies","type":"PLAINTEXT","enabled":true,"url":"https://example.com/movies.txt","filters":[{"id":5}]}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/lists"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/lists": func(req *http.Request) {
+			var list List
+			if err := json.NewDecoder(req.Body).Decode(&list); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if list.Type != ListTypePlaintext || !list.MatchRelease || len(list.Filters) != 1 || list.Filters[0].ID != 5 {
+				t.Errorf("Unexpected request body: %+v", list)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	list, err := client.CreateList(&List{
+		Name:         "Movies",
+		Type:         ListTypePlaintext,
+		Enabled:      true,
+		URL:          "https://example.com/movies.txt",
+		Filters:      []ListFilter{{ID: 5}},
+		MatchRelease: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.ID != 3 {
+		t.Errorf("Expected list ID 3, got %d", list.ID)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestListUpdateRefreshDelete(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/lists/3":         {statusCode: http.StatusNoContent},
+		"/api/lists/3/refresh": {statusCode: http.StatusNoContent},
+		"/api/lists/refresh":   {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "PUT", url: "/api/lists/3"},
+		{method: "POST", url: "/api/lists/3/refresh"},
+		{method: "POST", url: "/api/lists/refresh"},
+		{method: "DELETE", url: "/api/lists/3"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	updated, err := client.UpdateList(3, &List{ID: 3, Name: "Renamed", Type: ListTypePlaintext})
+	if err != nil {
+		t.Fatalf("Expected no error from UpdateList, got %v", err)
+	}
+	if updated.Name != "Renamed" {
+		t.Errorf("Expected submitted list to be returned, got %+v", updated)
+	}
+	if err := client.RefreshList(3); err != nil {
+		t.Fatalf("Expected no error from RefreshList, got %v", err)
+	}
+	if err := client.RefreshAllLists(); err != nil {
+		t.Fatalf("Expected no error from RefreshAllLists, got %v", err)
+	}
+	if err := client.DeleteList(3); err != nil {
+		t.Fatalf("Expected no error from DeleteList, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestRefreshList_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/lists/9/refresh": {statusCode: http.StatusNotFound, responseBody: "list not found"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/lists/9/refresh"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	if err := client.RefreshList(9); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
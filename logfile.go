@@ -0,0 +1,50 @@
+package autobrr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// LogFile describes a log file kept by the server
+type LogFile struct {
+	Filename string `json:"filename"`
+	// SizeBytes is the file size in bytes; Size is the same size formatted for display, e.g. "1.2 MB"
+	SizeBytes int64  `json:"size_bytes"`
+	Size      string `json:"size"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// logFilesResponse is the envelope of the log file list
+type logFilesResponse struct {
+	Files []LogFile `json:"files"`
+	Count int       `json:"count"`
+}
+
+// ListLogFiles retrieves the log files kept by the server
+func (c *Client) ListLogFiles() ([]LogFile, error) {
+	var response logFilesResponse
+	if err := c.doGetJSON("ListLogFiles", "/api/logs/files", &response); err != nil {
+		return nil, fmt.Errorf("list log files error: %w", err)
+	}
+
+	return response.Files, nil
+}
+
+// DownloadLogFile writes the log file name to w as it is received, without
+// holding the file in memory. Parse the contents with ParseLogs.
+func (c *Client) DownloadLogFile(name string, w io.Writer) error {
+	endpoint := "/api/logs/files/" + url.PathEscape(name)
+	resp, err := c.openResponse(context.Background(), "DownloadLogFile", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("download log file error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("download log file error: %w", err)
+	}
+
+	return nil
+}
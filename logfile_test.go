@@ -0,0 +1,93 @@
+package autobrr
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListLogFiles(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/logs/files": {
+			statusCode:   http.StatusOK,
+			responseBody: `{"files":[{"filename":"autobrr.log","size_bytes":2048,"size":"2.0 kB","updated_at":"2024-05-01T10:00:00Z"}],"count":1}`,
+		},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/logs/files"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	files, err := client.ListLogFiles()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(files) != 1 || files[0].Filename != "autobrr.log" || files[0].SizeBytes != 2048 {
+		t.Errorf("Unexpected files: %+v", files)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestDownloadLogFile(t *testing.T) {
+	content := "2024-05-01T10:00:00Z INF Starting autobrr module=main\n2024-05-01T10:00:01Z ERR Filter failed module=filter\n"
+	endpointResponses := map[string]mockResponse{
+		"/api/logs/files/autobrr.log": {statusCode: http.StatusOK, responseBody: content},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/logs/files/autobrr.log"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.DownloadLogFile("autobrr.log", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != content {
+		t.Errorf("Expected the file content, got %q", buf.String())
+	}
+
+	entries, err := ParseLogs(&buf, LogFilter{MinLevel: LogLevelError})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 1 || entries[0].Module != "filter" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
+
+func TestDownloadLogFile_NotFound(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/logs/files/missing.log": {statusCode: http.StatusNotFound, responseBody: "not found"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/logs/files/missing.log"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = client.DownloadLogFile("missing.log", &buf)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
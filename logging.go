@@ -0,0 +1,278 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// redacted replaces credentials in logged headers and bodies
+const redacted = "[REDACTED]"
+
+// defaultMaxLoggedBody is the number of body bytes logged when LogOptions.MaxBodyBytes is zero
+const defaultMaxLoggedBody = 4096
+
+// sensitiveHeaders are always redacted from logged headers
+var sensitiveHeaders = []string{"X-API-Token", "Authorization", "Cookie", "Set-Cookie"}
+
+// sensitivePaths are endpoints whose next path segment is a credential, such as
+// the key in DELETE /api/keys/{key}
+var sensitivePaths = []string{"/api/keys/"}
+
+// sensitiveFields are JSON keys whose values are always redacted from logged bodies.
+// They cover indexer settings such as passkeys, download client and user credentials,
+// and notification webhook URLs, which embed their token.
+var sensitiveFields = map[string]bool{
+	"passkey":      true,
+	"authkey":      true,
+	"torrent_pass": true,
+	"rsskey":       true,
+	"apikey":       true,
+	"api_key":      true,
+	"api_user":     true,
+	"key":          true,
+	"token":        true,
+	"password":     true,
+	"pass":         true,
+	"cookie":       true,
+	"secret":       true,
+	"uid":          true,
+	"webhook":      true,
+}
+
+// LogOptions configures LoggingMiddleware
+type LogOptions struct {
+	// Bodies additionally logs redacted request and response headers and bodies
+	Bodies bool
+
+	// MaxBodyBytes truncates logged bodies. Only this much of a response is
+	// buffered for logging; the rest streams to the caller. Zero means 4096 bytes.
+	MaxBodyBytes int
+}
+
+// WithLogger logs every request made by the client to logger
+func WithLogger(logger *slog.Logger, opts LogOptions) Option {
+	return WithMiddleware(LoggingMiddleware(logger, opts))
+}
+
+// LoggingMiddleware returns middleware that logs the operation, method, endpoint, status and
+// latency of each request. Successful requests are logged at debug level and
+// failures at error level. The X-API-Token header, API keys in paths and
+// credential fields in bodies are always redacted.
+func LoggingMiddleware(logger *slog.Logger, opts LogOptions) Middleware {
+	maxBody := opts.MaxBodyBytes
+	if maxBody <= 0 {
+		maxBody = defaultMaxLoggedBody
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			attrs := []slog.Attr{
+				slog.String("operation", OperationFromContext(req.Context())),
+				slog.String("method", req.Method),
+				slog.String("endpoint", redactPath(req.URL.Path)),
+			}
+
+			if opts.Bodies {
+				attrs = append(attrs, slog.Any("request_headers", redactHeaders(req.Header)))
+				if req.GetBody != nil {
+					if body, err := req.GetBody(); err == nil {
+						data, _ := io.ReadAll(body)
+						body.Close()
+						attrs = append(attrs, slog.String("request_body", formatBody(data, maxBody)))
+					}
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+			if err != nil {
+				// Transport errors quote the URL, including any key in its path
+				errMsg := strings.ReplaceAll(err.Error(), req.URL.EscapedPath(), redactPath(req.URL.Path))
+				attrs = append(attrs, slog.String("error", errMsg))
+				logger.LogAttrs(req.Context(), slog.LevelError, "autobrr request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+			// Event streams never end, so their bodies cannot be logged
+			isStream := strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+
+			if opts.Bodies && !isStream {
+				attrs = append(attrs, slog.Any("response_headers", redactHeaders(resp.Header)))
+
+				// Only the logged prefix is buffered; one byte more tells whether
+				// it is truncated. The caller reads the prefix and then the rest
+				// of the body, so large downloads are still streamed and the
+				// maximum response size still applies.
+				data, readErr := io.ReadAll(io.LimitReader(resp.Body, int64(maxBody)+1))
+				var rest io.Reader = resp.Body
+				if readErr != nil {
+					// Hand the error to the caller when it reads the body
+					rest = errReader{readErr}
+				}
+				resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), rest), Closer: resp.Body}
+				attrs = append(attrs, slog.String("response_body", formatBody(data, maxBody)))
+			}
+
+			level := slog.LevelDebug
+			msg := "autobrr request"
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				level = slog.LevelError
+				msg = "autobrr request failed"
+			}
+			logger.LogAttrs(req.Context(), level, msg, attrs...)
+
+			return resp, nil
+		})
+	}
+}
+
+// errReader returns err from every Read
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// readCloser combines a reader with the Closer of the body it reads from
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// redactPath redacts credentials embedded in an endpoint path
+func redactPath(path string) string {
+	for _, prefix := range sensitivePaths {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && rest != "" {
+			if i := strings.IndexByte(rest, '/'); i >= 0 {
+				return prefix + redacted + rest[i:]
+			}
+			return prefix + redacted
+		}
+	}
+
+	return path
+}
+
+// redactHeaders returns a copy of h with credential headers redacted
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+
+	return out
+}
+
+// formatBody redacts credentials from a JSON body and truncates it to max bytes.
+// Bodies longer than max, which may be cut off JSON, are truncated first and
+// redacted textually. Other bodies that are not JSON are logged as they are.
+func formatBody(data []byte, max int) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if len(data) > max {
+		return redactText(string(data[:max])) + "...(truncated)"
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err == nil {
+		if redactedData, err := json.Marshal(redactValue(v)); err == nil {
+			data = redactedData
+		}
+	}
+
+	if len(data) > max {
+		return string(data[:max]) + "...(truncated)"
+	}
+
+	return string(data)
+}
+
+// redactValue walks a decoded JSON value and redacts credential fields.
+// Settings stored as {"name": "passkey", "value": "..."} pairs are redacted too.
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if name, ok := val["name"].(string); ok && isSensitiveField(name) {
+			if _, ok := val["value"]; ok {
+				val["value"] = redacted
+			}
+		}
+		for k, child := range val {
+			if isSensitiveField(k) {
+				if s, ok := child.(string); ok && s == "" {
+					continue
+				}
+				val[k] = redacted
+				continue
+			}
+			val[k] = redactValue(child)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// jsonValuePattern matches a JSON string, which may be cut off at the end, or a scalar
+const jsonValuePattern = `("(?:[^"\\]|\\.)*"?|[^,{}\[\]\s"]+)`
+
+var (
+	// fieldPattern matches "key": value
+	fieldPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*:\s*` + jsonValuePattern)
+	// namedValuePattern matches "name": "key", ... "value": value within one object
+	namedValuePattern = regexp.MustCompile(`("name"\s*:\s*"((?:[^"\\]|\\.)*)"[^{}]*?"value"\s*:\s*)` + jsonValuePattern)
+	// valueNamedPattern matches "value": value, ... "name": "key" within one object
+	valueNamedPattern = regexp.MustCompile(`("value"\s*:\s*)` + jsonValuePattern + `([^{}]*?"name"\s*:\s*"((?:[^"\\]|\\.)*)")`)
+)
+
+// redactText redacts credential fields from JSON that cannot be decoded,
+// such as a body truncated for logging
+func redactText(s string) string {
+	s = namedValuePattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := namedValuePattern.FindStringSubmatch(m)
+		if !isSensitiveField(sub[2]) {
+			return m
+		}
+		return sub[1] + `"` + redacted + `"`
+	})
+	s = valueNamedPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := valueNamedPattern.FindStringSubmatch(m)
+		if !isSensitiveField(sub[4]) {
+			return m
+		}
+		return sub[1] + `"` + redacted + `"` + sub[3]
+	})
+
+	return fieldPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := fieldPattern.FindStringSubmatch(m)
+		if !isSensitiveField(sub[1]) || sub[2] == `""` {
+			return m
+		}
+		return strings.TrimSuffix(m, sub[2]) + `"` + redacted + `"`
+	})
+}
+
+func isSensitiveField(name string) bool {
+	return sensitiveFields[strings.ToLower(name)]
+}
@@ -0,0 +1,267 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newLoggedMockClient(t *testing.T, responses map[string]mockResponse, expectedRequests []expectedRequest, opts LogOptions) (*Client, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	transport := &mockRoundTripper{
+		responses:        responses,
+		expectedRequests: expectedRequests,
+		t:                t,
+	}
+
+	client, err := NewClientWithOptions("test-api-key", "localhost", "10798",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithLogger(logger, opts),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client, &buf
+}
+
+func TestWithLogger(t *testing.T) {
+	client, buf := newLoggedMockClient(t, map[string]mockResponse{
+		"/api/filters/1": {statusCode: http.StatusOK, responseBody: `{"id":1,"name":"TV"}`},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/filters/1"},
+	}, LogOptions{})
+
+	if _, err := client.GetFilter(1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode log entry %q: %v", buf.String(), err)
+	}
+
+	if entry["level"] != "DEBUG" || entry["operation"] != "GetFilter" || entry["method"] != "GET" || entry["endpoint"] != "/api/filters/1" || entry["status"] != float64(200) {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+	if _, ok := entry["latency"]; !ok {
+		t.Errorf("Expected latency to be logged, got %v", entry)
+	}
+	if _, ok := entry["response_body"]; ok {
+		t.Errorf("Expected bodies not to be logged by default, got %v", entry)
+	}
+}
+
+func TestWithLogger_RedactsCredentials(t *testing.T) {
+	client, buf := newLoggedMockClient(t, map[string]mockResponse{
+		"/api/filters": {statusCode: http.StatusBadRequest, responseBody: `{"message":"invalid","indexers":[{"id":1,"settings":{"passkey":"secret-passkey"}}]}`},
+	}, []expectedRequest{
+		{method: "POST", url: "/api/filters"},
+	}, LogOptions{Bodies: true})
+
+	filter := &Filter{
+		Name: "TV",
+		Indexers: []Indexer{{
+			ID:       1,
+			Settings: map[string]interface{}{"authkey": "secret-authkey", "torrent_pass": "secret-pass"},
+		}},
+	}
+	if _, err := client.CreateFilter(filter); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"test-api-key", "secret-passkey", "secret-authkey", "secret-pass"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, output)
+		}
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode log entry %q: %v", output, err)
+	}
+	if entry["level"] != "ERROR" || entry["status"] != float64(400) {
+		t.Errorf("Expected failed request to be logged at error level, got %v", entry)
+	}
+	if !strings.Contains(entry["request_body"].(string), `"name":"TV"`) {
+		t.Errorf("Expected request body to be logged, got %v", entry["request_body"])
+	}
+	if !strings.Contains(entry["response_body"].(string), "invalid") {
+		t.Errorf("Expected response body to be logged, got %v", entry["response_body"])
+	}
+}
+
+func TestWithLogger_RedactsNotificationWebhook(t *testing.T) {
+	webhook := "https://discord.com/api/webhooks/123/secret-webhook-token"
+	client, buf := newLoggedMockClient(t, map[string]mockResponse{
+		"/api/notification": {statusCode: http.StatusCreated, responseBody: `{"id":1,"name":"Discord","type":"DISCORD","webhook":"` + webhook + `"}`},
+	}, []expectedRequest{
+		{method: "POST", url: "/api/notification"},
+	}, LogOptions{Bodies: true})
+
+	notification := NewNotification("Discord", DiscordSettings{Webhook: webhook}, NotificationEventPushApproved)
+	if _, err := client.CreateNotification(notification); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "secret-webhook-token") {
+		t.Errorf("Expected the webhook to be redacted, got %s", output)
+	}
+	if !strings.Contains(output, `\"name\":\"Discord\"`) {
+		t.Errorf("Expected the notification body to be logged, got %s", output)
+	}
+}
+
+func TestFormatBody(t *testing.T) {
+	body := `{"settings":[{"name":"passkey","value":"abc"},{"name":"freeleech","value":"1"}],"password":"hunter2"}`
+	out := formatBody([]byte(body), 4096)
+
+	if strings.Contains(out, "abc") || strings.Contains(out, "hunter2") {
+		t.Errorf("Expected credentials to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, `"value":"1"`) {
+		t.Errorf("Expected non-sensitive settings to be kept, got %s", out)
+	}
+
+	if out := formatBody([]byte("plain text body"), 5); out != "plain...(truncated)" {
+		t.Errorf("Expected truncated body, got %q", out)
+	}
+}
+
+func TestFormatBody_TruncatedJSON(t *testing.T) {
+	body := `{"settings":[{"name":"passkey","value":"abc"},{"value":"def","type":"secret","name":"rsskey"}],"uid":12345,"password":"hunter2","description":"long"}`
+
+	// Cut off in the middle of the password
+	max := strings.Index(body, "hunter2") + 3
+	out := formatBody([]byte(body), max)
+
+	for _, secret := range []string{"abc", "def", "12345", "hun"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, out)
+		}
+	}
+	if !strings.HasSuffix(out, "...(truncated)") {
+		t.Errorf("Expected truncated body, got %s", out)
+	}
+}
+
+// countingReader counts the bytes read from it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestLoggingMiddleware_StreamsLargeBodies(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	content := strings.Repeat("x", 1<<20)
+	body := &countingReader{r: strings.NewReader(content)}
+	next := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(body)}, nil
+	})
+
+	req := httptest.NewRequest("GET", "/api/logs/files/autobrr.log", nil)
+	resp, err := LoggingMiddleware(logger, LogOptions{Bodies: true, MaxBodyBytes: 16})(next).Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if body.n > 17 {
+		t.Errorf("Expected at most 17 bytes to be read for logging, got %d", body.n)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || string(data) != content {
+		t.Errorf("Expected the full body for the caller, got %d bytes and %v", len(data), err)
+	}
+	if !strings.Contains(buf.String(), "...(truncated)") {
+		t.Errorf("Expected the logged body to be truncated, got %s", buf.String())
+	}
+}
+
+func TestWithLogger_MaxResponseSize(t *testing.T) {
+	client, buf := newLoggedMockClient(t, map[string]mockResponse{
+		"/api/filters": {statusCode: http.StatusOK, responseBody: `[{"id":1,"name":"` + strings.Repeat("x", 200) + `"}]`},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/filters"},
+	}, LogOptions{Bodies: true, MaxBodyBytes: 16})
+	WithMaxResponseSize(64)(client)
+
+	if _, err := client.GetFilters(); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "...(truncated)") {
+		t.Errorf("Expected the truncated body to be logged, got %s", buf.String())
+	}
+}
+
+func TestWithLogger_RedactsKeyInPath(t *testing.T) {
+	client, buf := newLoggedMockClient(t, map[string]mockResponse{
+		"/api/keys/SUPERSECRETKEY123": {statusCode: http.StatusInternalServerError, responseBody: "internal error"},
+	}, []expectedRequest{
+		{method: "DELETE", url: "/api/keys/SUPERSECRETKEY123"},
+	}, LogOptions{})
+
+	if err := client.DeleteAPIKey("SUPERSECRETKEY123"); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "SUPERSECRETKEY123") {
+		t.Errorf("Expected the key to be redacted, got %s", output)
+	}
+	if !strings.Contains(output, `"endpoint":"/api/keys/[REDACTED]"`) {
+		t.Errorf("Expected the redacted endpoint to be logged, got %s", output)
+	}
+}
+
+func TestRedactPath(t *testing.T) {
+	tests := map[string]string{
+		"/api/keys/abc":   "/api/keys/[REDACTED]",
+		"/api/keys/abc/x": "/api/keys/[REDACTED]/x",
+		"/api/keys":       "/api/keys",
+		"/api/filters/1":  "/api/filters/1",
+		"/api/keys/":      "/api/keys/",
+	}
+
+	for path, expected := range tests {
+		if got := redactPath(path); got != expected {
+			t.Errorf("redactPath(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
+
+func TestLoggingMiddleware_RedactsKeyInTransportError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	req := httptest.NewRequest("DELETE", "http://localhost:7474/api/keys/SUPERSECRETKEY123", nil)
+	next := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Delete", URL: req.URL.String(), Err: errors.New("connection refused")}
+	})
+
+	if _, err := LoggingMiddleware(logger, LogOptions{})(next).Do(req); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if strings.Contains(buf.String(), "SUPERSECRETKEY123") {
+		t.Errorf("Expected the key to be redacted, got %s", buf.String())
+	}
+}
@@ -0,0 +1,346 @@
+package autobrr
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// LogLevel is the severity of an autobrr log entry
+type LogLevel string
+
+const (
+	LogLevelTrace LogLevel = "trace"
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+	LogLevelFatal LogLevel = "fatal"
+	LogLevelPanic LogLevel = "panic"
+)
+
+// logLevelRank orders levels by severity
+var logLevelRank = map[LogLevel]int{
+	LogLevelTrace: 0,
+	LogLevelDebug: 1,
+	LogLevelInfo:  2,
+	LogLevelWarn:  3,
+	LogLevelError: 4,
+	LogLevelFatal: 5,
+	LogLevelPanic: 6,
+}
+
+// ParseLogLevel converts the level names and abbreviations used by autobrr
+// ("INF", "WRN", "info", "WARN", ...) to a LogLevel. Unknown names are returned lowercased.
+func ParseLogLevel(s string) LogLevel {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "TRC", "TRACE":
+		return LogLevelTrace
+	case "DBG", "DEBUG":
+		return LogLevelDebug
+	case "INF", "INFO":
+		return LogLevelInfo
+	case "WRN", "WARN", "WARNING":
+		return LogLevelWarn
+	case "ERR", "ERROR":
+		return LogLevelError
+	case "FTL", "FATAL":
+		return LogLevelFatal
+	case "PNC", "PANIC":
+		return LogLevelPanic
+	default:
+		return LogLevel(strings.ToLower(s))
+	}
+}
+
+// AtLeast reports whether l is as severe as min. Unknown levels are never at least a known level.
+func (l LogLevel) AtLeast(min LogLevel) bool {
+	rank, ok := logLevelRank[l]
+	if !ok {
+		return false
+	}
+
+	return rank >= logLevelRank[min]
+}
+
+// LogEntry is a single structured autobrr log message
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	// Module is the autobrr component that logged the message, e.g. "filter" or "irc"
+	Module string
+	// Fields holds any other key/value pairs attached to the message
+	Fields map[string]string
+}
+
+// parseJSONLogEntry decodes a log entry in autobrr's JSON log format
+func parseJSONLogEntry(data []byte) (LogEntry, bool) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return LogEntry{}, false
+	}
+
+	var entry LogEntry
+	for k, v := range raw {
+		s := stringify(v)
+		switch k {
+		case "time":
+			entry.Time = parseLogTime(s)
+		case "level":
+			entry.Level = ParseLogLevel(s)
+		case "message":
+			entry.Message = s
+		case "module":
+			entry.Module = s
+		default:
+			if entry.Fields == nil {
+				entry.Fields = make(map[string]string)
+			}
+			entry.Fields[k] = s
+		}
+	}
+
+	return entry, true
+}
+
+// stringify renders a decoded JSON value as a string
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
+
+// logTimeLayouts are the timestamp formats written by autobrr
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+}
+
+// parseLogTime parses an autobrr log timestamp, returning the zero time when unrecognised
+func parseLogTime(s string) time.Time {
+	for _, layout := range logTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// ParseLogLine parses a line of an autobrr log file. Both the console format
+// ("2024-05-01T10:00:00Z INF message module=filter key=value") and the JSON
+// format are understood. Lines without a timestamp, such as continuation lines
+// of a stack trace, are not parsed.
+func ParseLogLine(line string) (LogEntry, bool) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "{") {
+		entry, ok := parseJSONLogEntry([]byte(line))
+		return entry, ok && !entry.Time.IsZero()
+	}
+
+	tokens := splitLogTokens(line)
+	if len(tokens) == 0 {
+		return LogEntry{}, false
+	}
+
+	// Timestamps such as "2006-01-02 15:04:05" span two tokens
+	entry := LogEntry{Time: parseLogTime(tokens[0].text)}
+	if entry.Time.IsZero() && len(tokens) > 1 {
+		entry.Time = parseLogTime(line[tokens[0].start:tokens[1].end])
+		if !entry.Time.IsZero() {
+			tokens = tokens[1:]
+		}
+	}
+	if entry.Time.IsZero() {
+		return LogEntry{}, false
+	}
+	tokens = tokens[1:]
+
+	if len(tokens) > 0 {
+		if level := ParseLogLevel(tokens[0].text); isKnownLogLevel(level) {
+			entry.Level = level
+			tokens = tokens[1:]
+		}
+	}
+
+	// Fields are the trailing key=value tokens; everything before them is the message
+	split := len(tokens)
+	for split > 0 && isLogField(tokens[split-1].text) {
+		split--
+	}
+	if split > 0 {
+		start, end := tokens[0].start, tokens[split-1].end
+		entry.Message = line[start:end]
+	}
+
+	for _, tok := range tokens[split:] {
+		key, value, _ := strings.Cut(tok.text, "=")
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		if key == "module" {
+			entry.Module = value
+			continue
+		}
+		if entry.Fields == nil {
+			entry.Fields = make(map[string]string)
+		}
+		entry.Fields[key] = value
+	}
+
+	return entry, true
+}
+
+// isKnownLogLevel reports whether l is one of the levels autobrr logs at
+func isKnownLogLevel(l LogLevel) bool {
+	_, ok := logLevelRank[l]
+	return ok
+}
+
+// logToken is a whitespace separated token of a console log line
+type logToken struct {
+	text       string
+	start, end int
+}
+
+// splitLogTokens splits a console log line on whitespace, keeping quoted values
+// such as error="connection refused" in one token
+func splitLogTokens(line string) []logToken {
+	var tokens []logToken
+
+	start := -1
+	inQuote, escaped := false, false
+	for i, r := range line {
+		switch {
+		case start < 0 && unicode.IsSpace(r):
+			continue
+		case start < 0:
+			start = i
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuote = !inQuote
+		case !inQuote && unicode.IsSpace(r):
+			tokens = append(tokens, logToken{text: line[start:i], start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, logToken{text: line[start:], start: start, end: len(line)})
+	}
+
+	return tokens
+}
+
+// isLogField reports whether a token is a key=value field
+func isLogField(token string) bool {
+	key, _, ok := strings.Cut(token, "=")
+	if !ok || key == "" {
+		return false
+	}
+
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LogFilter selects log entries. Zero fields match everything.
+type LogFilter struct {
+	// MinLevel only matches entries at least this severe
+	MinLevel LogLevel
+	// Modules only matches entries logged by one of these modules
+	Modules []string
+	// Since only matches entries at or after this time
+	Since time.Time
+	// Until only matches entries before this time
+	Until time.Time
+}
+
+// Match reports whether entry is selected by the filter
+func (f LogFilter) Match(entry LogEntry) bool {
+	if f.MinLevel != "" && !entry.Level.AtLeast(f.MinLevel) {
+		return false
+	}
+
+	if len(f.Modules) > 0 {
+		found := false
+		for _, module := range f.Modules {
+			if strings.EqualFold(module, entry.Module) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Time.Before(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// ParseLogs reads an autobrr log file, e.g. from DownloadLogFile, and returns
+// the entries matching filter. Lines that are not log entries, such as stack
+// traces, are appended to the message of the entry before them.
+func ParseLogs(r io.Reader, filter LogFilter) ([]LogEntry, error) {
+	var entries []LogEntry
+
+	var current *LogEntry
+	flush := func() {
+		if current != nil && filter.Match(*current) {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if entry, ok := ParseLogLine(line); ok {
+			flush()
+			current = &entry
+			continue
+		}
+
+		if current != nil && strings.TrimSpace(line) != "" {
+			current.Message += "\n" + line
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return entries, err
+	}
+
+	return entries, nil
+}
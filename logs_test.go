@@ -0,0 +1,143 @@
+package autobrr
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := map[string]LogLevel{
+		"INF":     LogLevelInfo,
+		"info":    LogLevelInfo,
+		"WRN":     LogLevelWarn,
+		"warning": LogLevelWarn,
+		"ERR":     LogLevelError,
+		"DBG":     LogLevelDebug,
+		"TRC":     LogLevelTrace,
+		"FTL":     LogLevelFatal,
+		"custom":  LogLevel("custom"),
+	}
+
+	for input, expected := range tests {
+		if got := ParseLogLevel(input); got != expected {
+			t.Errorf("ParseLogLevel(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestLogLevel_AtLeast(t *testing.T) {
+	if !LogLevelError.AtLeast(LogLevelWarn) {
+		t.Error("Expected error to be at least warn")
+	}
+	if LogLevelDebug.AtLeast(LogLevelInfo) {
+		t.Error("Expected debug not to be at least info")
+	}
+	if LogLevel("custom").AtLeast(LogLevelTrace) {
+		t.Error("Expected unknown level not to be at least trace")
+	}
+}
+
+func TestParseLogLine_Console(t *testing.T) {
+	entry, ok := ParseLogLine(`2024-05-01T10:00:00+02:00 INF Matched release module=filter filter="TV Shows" indexer=tl`)
+	if !ok {
+		t.Fatal("Expected the line to parse")
+	}
+
+	if !entry.Time.Equal(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time: %v", entry.Time)
+	}
+	if entry.Level != LogLevelInfo || entry.Module != "filter" || entry.Message != "Matched release" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if entry.Fields["filter"] != "TV Shows" || entry.Fields["indexer"] != "tl" {
+		t.Errorf("Unexpected fields: %v", entry.Fields)
+	}
+}
+
+func TestParseLogLine_SpaceSeparatedTime(t *testing.T) {
+	entry, ok := ParseLogLine("2024-05-01 10:00:00 INF hello module=filter")
+	if !ok {
+		t.Fatal("Expected the line to parse")
+	}
+
+	if !entry.Time.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time: %v", entry.Time)
+	}
+	if entry.Level != LogLevelInfo || entry.Module != "filter" || entry.Message != "hello" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+}
+
+func TestParseLogLine_JSON(t *testing.T) {
+	entry, ok := ParseLogLine(`{"time":"2024-05-01T10:00:00Z","level":"warn","module":"irc","message":"Reconnecting"}`)
+	if !ok {
+		t.Fatal("Expected the line to parse")
+	}
+
+	if entry.Level != LogLevelWarn || entry.Module != "irc" || entry.Message != "Reconnecting" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+}
+
+func TestParseLogLine_NotAnEntry(t *testing.T) {
+	for _, line := range []string{"", "goroutine 1 [running]:", "\tmain.go:12 +0x1d"} {
+		if _, ok := ParseLogLine(line); ok {
+			t.Errorf("Expected %q not to parse", line)
+		}
+	}
+}
+
+func TestLogFilter_Match(t *testing.T) {
+	entry := LogEntry{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Level: LogLevelWarn, Module: "irc"}
+
+	tests := []struct {
+		name     string
+		filter   LogFilter
+		expected bool
+	}{
+		{"empty", LogFilter{}, true},
+		{"level below", LogFilter{MinLevel: LogLevelInfo}, true},
+		{"level above", LogFilter{MinLevel: LogLevelError}, false},
+		{"module", LogFilter{Modules: []string{"filter", "IRC"}}, true},
+		{"other module", LogFilter{Modules: []string{"filter"}}, false},
+		{"since inclusive", LogFilter{Since: entry.Time}, true},
+		{"until exclusive", LogFilter{Until: entry.Time}, false},
+		{"window", LogFilter{Since: entry.Time.Add(-time.Hour), Until: entry.Time.Add(time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Match(entry); got != tt.expected {
+			t.Errorf("%s: Match() = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLogs(t *testing.T) {
+	input := strings.Join([]string{
+		"2024-05-01T10:00:00Z INF Starting autobrr module=main",
+		"2024-05-01T10:00:01Z ERR Action failed module=action error=\"connection refused\"",
+		"goroutine 1 [running]:",
+		"2024-05-01 10:00:02 WRN Slow response module=irc",
+		"2024-05-01T10:00:03Z DBG Checking filter module=filter",
+		"",
+	}, "\n")
+
+	entries, err := ParseLogs(strings.NewReader(input), LogFilter{MinLevel: LogLevelInfo})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[1].Message != "Action failed\ngoroutine 1 [running]:" {
+		t.Errorf("Expected the continuation line to be appended, got %q", entries[1].Message)
+	}
+	if entries[1].Fields["error"] != "connection refused" {
+		t.Errorf("Unexpected fields: %v", entries[1].Fields)
+	}
+	if entries[2].Module != "irc" || entries[2].Message != "Slow response" {
+		t.Errorf("Expected the space separated timestamp to start an entry, got %+v", entries[2])
+	}
+}
@@ -0,0 +1,179 @@
+package autobrr
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives one observation per API request.
+// statusCode is zero when no response was received, and err is non-nil for
+// transport failures and non-2xx responses.
+type MetricsCollector interface {
+	ObserveRequest(operation string, statusCode int, err error, duration time.Duration)
+}
+
+// WithMetrics reports every request made by the client to collector
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = collector
+	}
+}
+
+// DefaultLatencyBuckets are the histogram upper bounds in seconds used by NewPrometheusMetrics
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics is a MetricsCollector exposing per-operation request counters,
+// error counters and latency histograms in the Prometheus text format.
+// It implements http.Handler so it can be mounted on a metrics endpoint.
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+
+	mu       sync.Mutex
+	requests map[requestLabels]uint64
+	errors   map[string]uint64
+	latency  map[string]*histogram
+}
+
+// requestLabels identifies a request counter series
+type requestLabels struct {
+	operation string
+	code      string
+}
+
+// histogram holds cumulative bucket counts for one operation
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+var _ MetricsCollector = (*PrometheusMetrics)(nil)
+var _ http.Handler = (*PrometheusMetrics)(nil)
+
+// NewPrometheusMetrics creates a collector whose metric names are prefixed with
+// namespace, e.g. "myservice" yields myservice_autobrr_client_requests_total.
+// An empty namespace omits the prefix. Latency buckets default to DefaultLatencyBuckets.
+func NewPrometheusMetrics(namespace string, buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		namespace: namespace,
+		buckets:   buckets,
+		requests:  make(map[requestLabels]uint64),
+		errors:    make(map[string]uint64),
+		latency:   make(map[string]*histogram),
+	}
+}
+
+// ObserveRequest records a request
+func (m *PrometheusMetrics) ObserveRequest(operation string, statusCode int, err error, duration time.Duration) {
+	code := "error"
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestLabels{operation: operation, code: code}]++
+	if err != nil {
+		m.errors[operation]++
+	}
+
+	h, ok := m.latency[operation]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latency[operation] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, m.String())
+}
+
+// String returns all metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	name := m.metricName("requests_total")
+	fmt.Fprintf(&b, "# HELP %s Total number of autobrr API requests by operation and status code.\n", name)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+	keys := make([]requestLabels, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s{operation=%q,code=%q} %d\n", name, k.operation, k.code, m.requests[k])
+	}
+
+	name = m.metricName("request_errors_total")
+	fmt.Fprintf(&b, "# HELP %s Total number of failed autobrr API requests by operation.\n", name)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+	for _, op := range sortedKeys(m.errors) {
+		fmt.Fprintf(&b, "%s{operation=%q} %d\n", name, op, m.errors[op])
+	}
+
+	name = m.metricName("request_duration_seconds")
+	fmt.Fprintf(&b, "# HELP %s Latency of autobrr API requests by operation.\n", name)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+	for _, op := range sortedKeys(m.latency) {
+		h := m.latency[op]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(&b, "%s_bucket{operation=%q,le=%q} %d\n", name, op, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{operation=%q,le=\"+Inf\"} %d\n", name, op, h.count)
+		fmt.Fprintf(&b, "%s_sum{operation=%q} %s\n", name, op, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count{operation=%q} %d\n", name, op, h.count)
+	}
+
+	return b.String()
+}
+
+// metricName builds a fully qualified metric name
+func (m *PrometheusMetrics) metricName(name string) string {
+	if m.namespace == "" {
+		return "autobrr_client_" + name
+	}
+
+	return m.namespace + "_autobrr_client_" + name
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,111 @@
+package autobrr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// observation is a single call recorded by recordingCollector
+type observation struct {
+	operation  string
+	statusCode int
+	err        error
+}
+
+type recordingCollector struct {
+	observations []observation
+}
+
+func (r *recordingCollector) ObserveRequest(operation string, statusCode int, err error, duration time.Duration) {
+	r.observations = append(r.observations, observation{operation, statusCode, err})
+}
+
+func TestWithMetrics(t *testing.T) {
+	transport := &mockRoundTripper{
+		responses: map[string]mockResponse{
+			"/api/filters":   {statusCode: http.StatusOK, responseBody: `[]`},
+			"/api/filters/1": {statusCode: http.StatusNotFound, responseBody: `not found`},
+		},
+		expectedRequests: []expectedRequest{
+			{method: "GET", url: "/api/filters"},
+			{method: "PUT", url: "/api/filters/1"},
+		},
+		t: t,
+	}
+
+	collector := &recordingCollector{}
+	client, err := NewClientWithOptions("test-api-key", "localhost", "10798",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithMetrics(collector),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.UpdateFilter(1, &Filter{Name: "TV"}); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if len(collector.observations) != 2 {
+		t.Fatalf("Expected 2 observations, got %d", len(collector.observations))
+	}
+	if o := collector.observations[0]; o.operation != "GetFilters" || o.statusCode != 200 || o.err != nil {
+		t.Errorf("Unexpected observation: %+v", o)
+	}
+	if o := collector.observations[1]; o.operation != "UpdateFilter" || o.statusCode != 404 || o.err == nil {
+		t.Errorf("Unexpected observation: %+v", o)
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics("", 0.1, 1)
+	m.ObserveRequest("GetFilters", 200, nil, 50*time.Millisecond)
+	m.ObserveRequest("GetFilters", 200, nil, 500*time.Millisecond)
+	m.ObserveRequest("UpdateFilter", 0, errors.New("connection refused"), 2*time.Second)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected text/plain content type, got %q", ct)
+	}
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		`# TYPE autobrr_client_requests_total counter`,
+		`autobrr_client_requests_total{operation="GetFilters",code="200"} 2`,
+		`autobrr_client_requests_total{operation="UpdateFilter",code="error"} 1`,
+		`autobrr_client_request_errors_total{operation="UpdateFilter"} 1`,
+		`# TYPE autobrr_client_request_duration_seconds histogram`,
+		`autobrr_client_request_duration_seconds_bucket{operation="GetFilters",le="0.1"} 1`,
+		`autobrr_client_request_duration_seconds_bucket{operation="GetFilters",le="1"} 2`,
+		`autobrr_client_request_duration_seconds_bucket{operation="GetFilters",le="+Inf"} 2`,
+		`autobrr_client_request_duration_seconds_sum{operation="GetFilters"} 0.55`,
+		`autobrr_client_request_duration_seconds_count{operation="UpdateFilter"} 1`,
+		`autobrr_client_request_duration_seconds_bucket{operation="UpdateFilter",le="1"} 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+
+	if strings.Contains(body, `request_errors_total{operation="GetFilters"}`) {
+		t.Errorf("Expected no error series for GetFilters, got:\n%s", body)
+	}
+}
+
+func TestPrometheusMetrics_Namespace(t *testing.T) {
+	m := NewPrometheusMetrics("sync")
+	m.ObserveRequest("GetFilter", 200, nil, time.Millisecond)
+
+	if !strings.Contains(m.String(), `sync_autobrr_client_requests_total{operation="GetFilter",code="200"} 1`) {
+		t.Errorf("Expected namespaced metric, got:\n%s", m.String())
+	}
+}
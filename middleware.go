@@ -0,0 +1,47 @@
+package autobrr
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer used by the client to send every API request.
+// It can inspect or modify the request before calling next, and the response after.
+type Middleware func(next Doer) Doer
+
+// chainMiddleware wraps doer so that middleware[0] is the outermost
+func chainMiddleware(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+
+	return doer
+}
+
+// operationKey is the context key holding the API operation name
+type operationKey struct{}
+
+// withOperation returns a copy of ctx carrying the API operation name
+func withOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the API operation, e.g. "GetFilters", that issued
+// the request carrying ctx. Middleware can use it to label requests.
+func OperationFromContext(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
@@ -0,0 +1,62 @@
+package autobrr
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	transport := &mockRoundTripper{
+		responses: map[string]mockResponse{
+			"/api/filters": {statusCode: http.StatusOK, responseBody: `[]`},
+		},
+		expectedRequests: []expectedRequest{
+			{method: "GET", url: "/api/filters"},
+		},
+		t: t,
+	}
+
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":"+req.URL.Path)
+				resp, err := next.Do(req)
+				if err == nil {
+					order = append(order, name+":"+strconv.Itoa(resp.StatusCode))
+				}
+				return resp, err
+			})
+		}
+	}
+
+	client, err := NewClientWithOptions("test-api-key", "localhost", "10798",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithMiddleware(record("outer")),
+		WithMiddleware(record("inner")),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"outer:/api/filters", "inner:/api/filters", "inner:200", "outer:200"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+
+	// Check the request made
+	if transport.requestIndex != len(transport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
@@ -0,0 +1,247 @@
+package autobrr
+
+import "fmt"
+
+// MigrateOptions controls how filters are copied from one instance to another
+type MigrateOptions struct {
+	// FilterIDs limits the migration to these source filter IDs. All filters are migrated when empty.
+	FilterIDs []int
+
+	// AllowUnresolved migrates filters even when some of their references could not
+	// be resolved on the target. Unresolved indexers are dropped and unresolved
+	// actions are migrated without a download client.
+	AllowUnresolved bool
+
+	// DryRun resolves references and fills in the report without writing to the target
+	DryRun bool
+}
+
+// ReferenceKind identifies the kind of instance-specific reference held by a filter
+type ReferenceKind string
+
+const (
+	ReferenceIndexer        ReferenceKind = "indexer"
+	ReferenceDownloadClient ReferenceKind = "download_client"
+)
+
+// UnresolvedReference describes a filter reference that has no match on the target instance
+type UnresolvedReference struct {
+	Filter   string        `json:"filter"`
+	Kind     ReferenceKind `json:"kind"`
+	SourceID int           `json:"source_id"`
+	// Name is the indexer identifier or download client name on the source, empty if the source does not know the ID either
+	Name string `json:"name,omitempty"`
+}
+
+// MigrationAction is the outcome of migrating a single filter
+type MigrationAction string
+
+const (
+	MigrationCreated MigrationAction = "created"
+	MigrationUpdated MigrationAction = "updated"
+	MigrationSkipped MigrationAction = "skipped"
+)
+
+// MigrationResult records what happened to a single filter
+type MigrationResult struct {
+	Name     string          `json:"name"`
+	SourceID int             `json:"source_id"`
+	TargetID int             `json:"target_id,omitempty"`
+	Action   MigrationAction `json:"action"`
+}
+
+// MigrationReport summarises a filter migration
+type MigrationReport struct {
+	Results    []MigrationResult     `json:"results"`
+	Unresolved []UnresolvedReference `json:"unresolved,omitempty"`
+}
+
+// MigrateFilters copies filters from src to dst.
+// Indexers are matched by Identifier and download clients by name; filters that
+// already exist on dst (matched by name) are updated, all others are created.
+// On a write error the report built so far is returned together with the error.
+func MigrateFilters(src, dst API, opts MigrateOptions) (*MigrationReport, error) {
+	srcIndexers, err := src.GetIndexers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source indexers: %v", err)
+	}
+	dstIndexers, err := dst.GetIndexers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target indexers: %v", err)
+	}
+	srcClients, err := src.GetDownloadClients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source download clients: %v", err)
+	}
+	dstClients, err := dst.GetDownloadClients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target download clients: %v", err)
+	}
+	srcFilters, err := src.GetFilters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source filters: %v", err)
+	}
+	dstFilters, err := dst.GetFilters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target filters: %v", err)
+	}
+
+	r := &referenceResolver{
+		srcIndexers: make(map[int]string, len(srcIndexers)),
+		dstIndexers: make(map[string]Indexer, len(dstIndexers)),
+		srcClients:  make(map[int]string, len(srcClients)),
+		dstClients:  make(map[string]int, len(dstClients)),
+	}
+	for _, idx := range srcIndexers {
+		r.srcIndexers[idx.ID] = idx.Identifier
+	}
+	for _, idx := range dstIndexers {
+		r.dstIndexers[idx.Identifier] = idx
+	}
+	for _, dc := range srcClients {
+		r.srcClients[dc.ID] = dc.Name
+	}
+	for _, dc := range dstClients {
+		r.dstClients[dc.Name] = dc.ID
+	}
+
+	existing := make(map[string]int, len(dstFilters))
+	for _, f := range dstFilters {
+		existing[f.Name] = f.ID
+	}
+
+	selected := make(map[int]bool, len(opts.FilterIDs))
+	for _, id := range opts.FilterIDs {
+		selected[id] = true
+	}
+
+	report := &MigrationReport{}
+	for _, summary := range srcFilters {
+		if len(selected) > 0 && !selected[summary.ID] {
+			continue
+		}
+
+		// The list endpoint omits actions, so fetch the full filter
+		filter, err := src.GetFilter(int64(summary.ID))
+		if err != nil {
+			return report, fmt.Errorf("failed to get source filter %q: %v", summary.Name, err)
+		}
+
+		migrated, unresolved := r.rewrite(filter)
+		report.Unresolved = append(report.Unresolved, unresolved...)
+
+		result := MigrationResult{Name: filter.Name, SourceID: filter.ID}
+		targetID, exists := existing[filter.Name]
+
+		switch {
+		case len(unresolved) > 0 && !opts.AllowUnresolved:
+			result.Action = MigrationSkipped
+			result.TargetID = targetID
+		case exists:
+			result.Action = MigrationUpdated
+			result.TargetID = targetID
+			if !opts.DryRun {
+				migrated.ID = targetID
+				for i := range migrated.Actions {
+					migrated.Actions[i].FilterID = int64(targetID)
+				}
+				if _, err := dst.UpdateFilter(int64(targetID), migrated); err != nil {
+					return report, fmt.Errorf("failed to update target filter %q: %v", filter.Name, err)
+				}
+			}
+		default:
+			result.Action = MigrationCreated
+			if !opts.DryRun {
+				created, err := dst.CreateFilter(migrated)
+				if err != nil {
+					return report, fmt.Errorf("failed to create target filter %q: %v", filter.Name, err)
+				}
+				result.TargetID = created.ID
+			}
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// referenceResolver maps instance-specific IDs from a source instance to a target instance
+type referenceResolver struct {
+	srcIndexers map[int]string     // source indexer ID -> identifier
+	dstIndexers map[string]Indexer // identifier -> target indexer
+	srcClients  map[int]string     // source download client ID -> name
+	dstClients  map[string]int     // name -> target download client ID
+}
+
+// rewrite returns a copy of filter with IDs, timestamps and statistics cleared
+// and all indexer and download client references pointing at the target
+func (r *referenceResolver) rewrite(filter *Filter) (*Filter, []UnresolvedReference) {
+	var unresolved []UnresolvedReference
+	out := *filter
+	out.ID = 0
+	out.CreatedAt = ""
+	out.UpdatedAt = ""
+	out.ActionsCount = 0
+	out.ActionsEnabledCount = 0
+	out.Downloads = nil
+
+	// Indexers are referenced both by the relation list and by IndexerIDs
+	var identifiers []string
+	sourceIDs := make(map[string]int)
+	addIdentifier := func(sourceID int, identifier string) {
+		if identifier == "" {
+			unresolved = append(unresolved, UnresolvedReference{Filter: filter.Name, Kind: ReferenceIndexer, SourceID: sourceID})
+			return
+		}
+		if _, ok := sourceIDs[identifier]; !ok {
+			sourceIDs[identifier] = sourceID
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	for _, idx := range filter.Indexers {
+		identifier := idx.Identifier
+		if identifier == "" {
+			identifier = r.srcIndexers[idx.ID]
+		}
+		addIdentifier(idx.ID, identifier)
+	}
+	for _, id := range filter.IndexerIDs {
+		addIdentifier(id, r.srcIndexers[id])
+	}
+
+	out.Indexers = nil
+	out.IndexerIDs = nil
+	for _, identifier := range identifiers {
+		idx, ok := r.dstIndexers[identifier]
+		if !ok {
+			unresolved = append(unresolved, UnresolvedReference{Filter: filter.Name, Kind: ReferenceIndexer, SourceID: sourceIDs[identifier], Name: identifier})
+			continue
+		}
+		out.Indexers = append(out.Indexers, idx)
+		out.IndexerIDs = append(out.IndexerIDs, idx.ID)
+	}
+
+	out.Actions = nil
+	for _, action := range filter.Actions {
+		action.ID = 0
+		action.FilterID = 0
+		if action.ClientID != 0 {
+			name := r.srcClients[action.ClientID]
+			id, ok := r.dstClients[name]
+			if name == "" || !ok {
+				unresolved = append(unresolved, UnresolvedReference{Filter: filter.Name, Kind: ReferenceDownloadClient, SourceID: action.ClientID, Name: name})
+			}
+			action.ClientID = id
+		}
+		out.Actions = append(out.Actions, action)
+	}
+
+	out.External = nil
+	for _, ext := range filter.External {
+		ext.ID = 0
+		out.External = append(out.External, ext)
+	}
+
+	return &out, unresolved
+}
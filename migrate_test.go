@@ -0,0 +1,170 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// newMigrationSource returns a mock source instance holding a single filter
+// that references indexer 7 (btn) and download client 3 (qbit)
+func newMigrationSource(t *testing.T) (*Client, *mockRoundTripper) {
+	filter := Filter{
+		ID:         5,
+		Name:       "TV",
+		Enabled:    true,
+		CreatedAt:  "2024-01-01T00:00:00Z",
+		IndexerIDs: []int{7},
+		Indexers:   []Indexer{{ID: 7, Identifier: "btn"}},
+		Actions:    []Action{{ID: 11, Name: "qbit", Type: "QBITTORRENT", ClientID: 3, FilterID: 5}},
+	}
+	filterBody, _ := json.Marshal(filter)
+
+	client, transport, err := newMockClient(map[string]mockResponse{
+		"/api/indexer":          {statusCode: http.StatusOK, responseBody: `[{"id":7,"identifier":"btn"}]`},
+		"/api/download_clients": {statusCode: http.StatusOK, responseBody: `[{"id":3,"name":"qbit"}]`},
+		"/api/filters":          {statusCode: http.StatusOK, responseBody: `[{"id":5,"name":"TV"}]`},
+		"/api/filters/5":        {statusCode: http.StatusOK, responseBody: string(filterBody)},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/indexer"},
+		{method: "GET", url: "/api/download_clients"},
+		{method: "GET", url: "/api/filters"},
+		{method: "GET", url: "/api/filters/5"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client, transport
+}
+
+func TestMigrateFilters_Create(t *testing.T) {
+	src, srcTransport := newMigrationSource(t)
+
+	var received Filter
+	dst, dstTransport, err := newMockClientWithHandler(map[string]mockResponse{
+		"/api/indexer":          {statusCode: http.StatusOK, responseBody: `[{"id":70,"identifier":"btn"}]`},
+		"/api/download_clients": {statusCode: http.StatusOK, responseBody: `[{"id":30,"name":"qbit"}]`},
+		"GET /api/filters":      {statusCode: http.StatusOK, responseBody: `[]`},
+		"POST /api/filters":     {statusCode: http.StatusCreated, responseBody: `{"id":50,"name":"TV"}`},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/indexer"},
+		{method: "GET", url: "/api/download_clients"},
+		{method: "GET", url: "/api/filters"},
+		{method: "POST", url: "/api/filters"},
+	}, map[string]func(*http.Request){
+		"/api/filters": func(req *http.Request) {
+			if req.Method != "POST" {
+				return
+			}
+			if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := MigrateFilters(src, dst, MigrateOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(report.Results) != 1 || report.Results[0].Action != MigrationCreated || report.Results[0].TargetID != 50 {
+		t.Errorf("Unexpected results: %+v", report.Results)
+	}
+	if len(report.Unresolved) != 0 {
+		t.Errorf("Expected no unresolved references, got %+v", report.Unresolved)
+	}
+
+	if received.ID != 0 || received.CreatedAt != "" {
+		t.Errorf("Expected ID and timestamps to be stripped, got id=%d created_at=%q", received.ID, received.CreatedAt)
+	}
+	if len(received.IndexerIDs) != 1 || received.IndexerIDs[0] != 70 {
+		t.Errorf("Expected indexer IDs [70], got %v", received.IndexerIDs)
+	}
+	if len(received.Indexers) != 1 || received.Indexers[0].ID != 70 {
+		t.Errorf("Expected indexer relation to target ID 70, got %+v", received.Indexers)
+	}
+	if len(received.Actions) != 1 || received.Actions[0].ClientID != 30 || received.Actions[0].ID != 0 {
+		t.Errorf("Expected action remapped to client 30 without ID, got %+v", received.Actions)
+	}
+
+	if srcTransport.requestIndex != len(srcTransport.expectedRequests) || dstTransport.requestIndex != len(dstTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestMigrateFilters_UpdateExisting(t *testing.T) {
+	src, _ := newMigrationSource(t)
+
+	dst, dstTransport, err := newMockClient(map[string]mockResponse{
+		"/api/indexer":          {statusCode: http.StatusOK, responseBody: `[{"id":70,"identifier":"btn"}]`},
+		"/api/download_clients": {statusCode: http.StatusOK, responseBody: `[{"id":30,"name":"qbit"}]`},
+		"/api/filters":          {statusCode: http.StatusOK, responseBody: `[{"id":9,"name":"TV"}]`},
+		"/api/filters/9":        {statusCode: http.StatusOK, responseBody: `{"id":9,"name":"TV"}`},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/indexer"},
+		{method: "GET", url: "/api/download_clients"},
+		{method: "GET", url: "/api/filters"},
+		{method: "PUT", url: "/api/filters/9"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := MigrateFilters(src, dst, MigrateOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(report.Results) != 1 || report.Results[0].Action != MigrationUpdated || report.Results[0].TargetID != 9 {
+		t.Errorf("Unexpected results: %+v", report.Results)
+	}
+
+	if dstTransport.requestIndex != len(dstTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestMigrateFilters_Unresolved(t *testing.T) {
+	src, _ := newMigrationSource(t)
+
+	// The target has neither the indexer nor the download client, so nothing is written
+	dst, dstTransport, err := newMockClient(map[string]mockResponse{
+		"/api/indexer":          {statusCode: http.StatusOK, responseBody: `[]`},
+		"/api/download_clients": {statusCode: http.StatusOK, responseBody: `[]`},
+		"/api/filters":          {statusCode: http.StatusOK, responseBody: `[]`},
+	}, []expectedRequest{
+		{method: "GET", url: "/api/indexer"},
+		{method: "GET", url: "/api/download_clients"},
+		{method: "GET", url: "/api/filters"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report, err := MigrateFilters(src, dst, MigrateOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(report.Results) != 1 || report.Results[0].Action != MigrationSkipped {
+		t.Errorf("Expected filter to be skipped, got %+v", report.Results)
+	}
+
+	if len(report.Unresolved) != 2 {
+		t.Fatalf("Expected 2 unresolved references, got %+v", report.Unresolved)
+	}
+	if report.Unresolved[0].Kind != ReferenceIndexer || report.Unresolved[0].Name != "btn" || report.Unresolved[0].SourceID != 7 {
+		t.Errorf("Unexpected indexer reference: %+v", report.Unresolved[0])
+	}
+	if report.Unresolved[1].Kind != ReferenceDownloadClient || report.Unresolved[1].Name != "qbit" || report.Unresolved[1].SourceID != 3 {
+		t.Errorf("Unexpected download client reference: %+v", report.Unresolved[1])
+	}
+
+	if dstTransport.requestIndex != len(dstTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
@@ -0,0 +1,291 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationType is the agent a notification is delivered through
+type NotificationType string
+
+const (
+	NotificationTypeDiscord   NotificationType = "DISCORD"
+	NotificationTypeTelegram  NotificationType = "TELEGRAM"
+	NotificationTypePushover  NotificationType = "PUSHOVER"
+	NotificationTypeGotify    NotificationType = "GOTIFY"
+	NotificationTypeNtfy      NotificationType = "NTFY"
+	NotificationTypeNotifiarr NotificationType = "NOTIFIARR"
+)
+
+// NotificationEvent is an event a notification can be sent for
+type NotificationEvent string
+
+const (
+	NotificationEventPushApproved       NotificationEvent = "PUSH_APPROVED"
+	NotificationEventPushRejected       NotificationEvent = "PUSH_REJECTED"
+	NotificationEventPushError          NotificationEvent = "PUSH_ERROR"
+	NotificationEventIRCDisconnected    NotificationEvent = "IRC_DISCONNECTED"
+	NotificationEventIRCReconnected     NotificationEvent = "IRC_RECONNECTED"
+	NotificationEventAppUpdateAvailable NotificationEvent = "APP_UPDATE_AVAILABLE"
+)
+
+// Notification represents a notification agent as sent over the wire.
+// autobrr stores the settings of every agent in the same flat set of fields;
+// use Settings and SetSettings to work with them per agent.
+type Notification struct {
+	ID        int                 `json:"id,omitempty"`
+	Name      string              `json:"name"`
+	Type      NotificationType    `json:"type"`
+	Enabled   bool                `json:"enabled"`
+	Events    []NotificationEvent `json:"events"`
+	Token     string              `json:"token,omitempty"`
+	APIKey    string              `json:"api_key,omitempty"`
+	Webhook   string              `json:"webhook,omitempty"`
+	Title     string              `json:"title,omitempty"`
+	Icon      string              `json:"icon,omitempty"`
+	Host      string              `json:"host,omitempty"`
+	Username  string              `json:"username,omitempty"`
+	Password  string              `json:"password,omitempty"`
+	Channel   string              `json:"channel,omitempty"`
+	Rooms     string              `json:"rooms,omitempty"`
+	Targets   string              `json:"targets,omitempty"`
+	Devices   string              `json:"devices,omitempty"`
+	Priority  int                 `json:"priority,omitempty"`
+	Topic     string              `json:"topic,omitempty"`
+	Sound     string              `json:"sound,omitempty"`
+	CreatedAt string              `json:"created_at,omitempty"`
+	UpdatedAt string              `json:"updated_at,omitempty"`
+}
+
+// NotificationSettings are the agent-specific settings of a notification.
+// Implemented by DiscordSettings, TelegramSettings, PushoverSettings,
+// GotifySettings, NtfySettings and NotifiarrSettings.
+type NotificationSettings interface {
+	// Type is the agent the settings belong to
+	Type() NotificationType
+
+	apply(n *Notification)
+}
+
+// DiscordSettings configures a Discord webhook
+type DiscordSettings struct {
+	Webhook string
+}
+
+// TelegramSettings configures a Telegram bot
+type TelegramSettings struct {
+	BotToken string
+	ChatID   string
+	// TopicID is the message thread of a forum chat, optional
+	TopicID string
+	// Sender is shown as the sender of the message, optional
+	Sender string
+	// Host overrides the Telegram API host, optional
+	Host string
+}
+
+// PushoverSettings configures Pushover
+type PushoverSettings struct {
+	APIToken string
+	UserKey  string
+	Priority int
+	Devices  string
+	Sound    string
+}
+
+// GotifySettings configures a Gotify server
+type GotifySettings struct {
+	Host     string
+	AppToken string
+}
+
+// NtfySettings configures an ntfy server. Host is the full topic URL, e.g. https://ntfy.sh/autobrr.
+type NtfySettings struct {
+	Host     string
+	Token    string
+	Username string
+	Password string
+	Priority int
+}
+
+// NotifiarrSettings configures Notifiarr
+type NotifiarrSettings struct {
+	APIKey string
+}
+
+func (DiscordSettings) Type() NotificationType   { return NotificationTypeDiscord }
+func (TelegramSettings) Type() NotificationType  { return NotificationTypeTelegram }
+func (PushoverSettings) Type() NotificationType  { return NotificationTypePushover }
+func (GotifySettings) Type() NotificationType    { return NotificationTypeGotify }
+func (NtfySettings) Type() NotificationType      { return NotificationTypeNtfy }
+func (NotifiarrSettings) Type() NotificationType { return NotificationTypeNotifiarr }
+
+func (s DiscordSettings) apply(n *Notification) {
+	n.Webhook = s.Webhook
+}
+
+func (s TelegramSettings) apply(n *Notification) {
+	n.Token = s.BotToken
+	n.Channel = s.ChatID
+	n.Topic = s.TopicID
+	n.Username = s.Sender
+	n.Host = s.Host
+}
+
+func (s PushoverSettings) apply(n *Notification) {
+	n.APIKey = s.APIToken
+	n.Token = s.UserKey
+	n.Priority = s.Priority
+	n.Devices = s.Devices
+	n.Sound = s.Sound
+}
+
+func (s GotifySettings) apply(n *Notification) {
+	n.Host = s.Host
+	n.Token = s.AppToken
+}
+
+func (s NtfySettings) apply(n *Notification) {
+	n.Host = s.Host
+	n.Token = s.Token
+	n.Username = s.Username
+	n.Password = s.Password
+	n.Priority = s.Priority
+}
+
+func (s NotifiarrSettings) apply(n *Notification) {
+	n.APIKey = s.APIKey
+}
+
+// NewNotification creates an enabled notification for the agent described by settings
+func NewNotification(name string, settings NotificationSettings, events ...NotificationEvent) *Notification {
+	n := &Notification{
+		Name:    name,
+		Enabled: true,
+		Events:  events,
+	}
+	n.SetSettings(settings)
+
+	return n
+}
+
+// SetSettings sets the agent type and its settings, clearing the settings of any previous agent
+func (n *Notification) SetSettings(settings NotificationSettings) {
+	*n = Notification{
+		ID:        n.ID,
+		Name:      n.Name,
+		Type:      settings.Type(),
+		Enabled:   n.Enabled,
+		Events:    n.Events,
+		Title:     n.Title,
+		Icon:      n.Icon,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+	settings.apply(n)
+}
+
+// Settings returns the typed settings of the notification's agent,
+// or nil for agents without typed settings
+func (n *Notification) Settings() NotificationSettings {
+	switch n.Type {
+	case NotificationTypeDiscord:
+		return DiscordSettings{Webhook: n.Webhook}
+	case NotificationTypeTelegram:
+		return TelegramSettings{BotToken: n.Token, ChatID: n.Channel, TopicID: n.Topic, Sender: n.Username, Host: n.Host}
+	case NotificationTypePushover:
+		return PushoverSettings{APIToken: n.APIKey, UserKey: n.Token, Priority: n.Priority, Devices: n.Devices, Sound: n.Sound}
+	case NotificationTypeGotify:
+		return GotifySettings{Host: n.Host, AppToken: n.Token}
+	case NotificationTypeNtfy:
+		return NtfySettings{Host: n.Host, Token: n.Token, Username: n.Username, Password: n.Password, Priority: n.Priority}
+	case NotificationTypeNotifiarr:
+		return NotifiarrSettings{APIKey: n.APIKey}
+	default:
+		return nil
+	}
+}
+
+// GetNotifications retrieves all notification agents
+func (c *Client) GetNotifications() ([]Notification, error) {
+	var notifications []Notification
+	if err := c.doGetJSON("GetNotifications", "/api/notification", &notifications); err != nil {
+		return nil, fmt.Errorf("get notifications error: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CreateNotification creates a new notification agent
+func (c *Client) CreateNotification(notification *Notification) (*Notification, error) {
+	jsonData, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %v", err)
+	}
+
+	respData, err := c.doPost("CreateNotification", "/api/notification", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("create notification error: %w", err)
+	}
+
+	createdNotification := *notification
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &createdNotification); err != nil {
+			return nil, fmt.Errorf("failed to decode created notification: %v", err)
+		}
+	}
+
+	return &createdNotification, nil
+}
+
+// UpdateNotification updates an existing notification agent.
+// When the server does not echo the notification back, the submitted notification is returned.
+func (c *Client) UpdateNotification(id int64, notification *Notification) (*Notification, error) {
+	jsonData, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("/api/notification/%d", id)
+	respData, err := c.doPut("UpdateNotification", endpoint, bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("update notification error: %w", err)
+	}
+
+	updatedNotification := *notification
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &updatedNotification); err != nil {
+			return nil, fmt.Errorf("failed to decode updated notification: %v", err)
+		}
+	}
+
+	return &updatedNotification, nil
+}
+
+// DeleteNotification deletes a notification agent by ID
+func (c *Client) DeleteNotification(id int64) error {
+	endpoint := fmt.Sprintf("/api/notification/%d", id)
+	_, err := c.doDelete("DeleteNotification", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete notification error: %w", err)
+	}
+
+	return nil
+}
+
+// TestNotification sends a test message through the given agent settings.
+// The notification does not need to be saved first.
+func (c *Client) TestNotification(notification *Notification) error {
+	jsonData, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %v", err)
+	}
+
+	_, err = c.doPost("TestNotification", "/api/notification/test", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("test notification error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestNotificationSettings_RoundTrip(t *testing.T) {
+	settings := []NotificationSettings{
+		DiscordSettings{Webhook: "https://discord.com/api/webhooks/1/abc"},
+		TelegramSettings{BotToken: "bot", ChatID: "-100", TopicID: "7", Sender: "autobrr"},
+		PushoverSettings{APIToken: "app", UserKey: "user", Priority: 1, Sound: "magic"},
+		GotifySettings{Host: "https://gotify.example.com", AppToken: "token"},
+		NtfySettings{Host: "https://ntfy.sh/autobrr", Username: "user", Password: "pass", Priority: 4},
+		NotifiarrSettings{APIKey: "key"},
+	}
+
+	for _, s := range settings {
+		n := NewNotification("test", s, NotificationEventPushApproved)
+		if n.Type != s.Type() || !n.Enabled || len(n.Events) != 1 {
+			t.Errorf("Unexpected notification for %s: %+v", s.Type(), n)
+		}
+		if got := n.Settings(); got != s {
+			t.Errorf("Expected settings %+v, got %+v", s, got)
+		}
+	}
+}
+
+func TestNotification_SetSettingsClearsPreviousAgent(t *testing.T) {
+	n := NewNotification("alerts", PushoverSettings{APIToken: "app", UserKey: "user", Priority: 2})
+	n.ID = 4
+
+	n.SetSettings(GotifySettings{Host: "https://gotify.example.com", AppToken: "token"})
+
+	if n.ID != 4 || n.Name != "alerts" || n.Type != NotificationTypeGotify {
+		t.Errorf("Expected identity to be kept, got %+v", n)
+	}
+	if n.APIKey != "" || n.Priority != 0 {
+		t.Errorf("Expected Pushover settings to be cleared, got %+v", n)
+	}
+	if n.Token != "token" {
+		t.Errorf("Expected Gotify token, got %q", n.Token)
+	}
+}
+
+func TestGetNotifications(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/notification": {statusCode: http.StatusOK, responseBody: `[
+			{"id":1,"name":"Telegram","type":"TELEGRAM","enabled":true,"events":["PUSH_APPROVED","IRC_DISCONNECTED"],"token":"bot","channel":"-100"},
+			{"id":2,"name":"Slack","type":"SLACK","enabled":false,"events":[],"webhook":"https://hooks.slack.com/x"}
+		]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/notification"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	notifications, err := client.GetNotifications()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notifications) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(notifications))
+	}
+	if s, ok := notifications[0].Settings().(TelegramSettings); !ok || s.BotToken != "bot" || s.ChatID != "-100" {
+		t.Errorf("Unexpected Telegram settings: %+v", notifications[0].Settings())
+	}
+	if notifications[0].Events[1] != NotificationEventIRCDisconnected {
+		t.Errorf("Unexpected events: %v", notifications[0].Events)
+	}
+	if notifications[1].Settings() != nil {
+		t.Errorf("Expected no typed settings for Slack, got %+v", notifications[1].Settings())
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestCreateNotification(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/notification": {statusCode: http.StatusCreated, responseBody: `{"id":3,"name":"Discord","type":"DISCORD","enabled":true,"events":["PUSH_ERROR"],"webhook":"https://discord.com/api/webhooks/1/abc"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/notification"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/notification": func(req *http.Request) {
+			var n Notification
+			if err := json.NewDecoder(req.Body).Decode(&n); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if n.Type != NotificationTypeDiscord || n.Webhook != "https://discord.com/api/webhooks/1/abc" {
+				t.Errorf("Unexpected request body: %+v", n)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	n := NewNotification("Discord", DiscordSettings{Webhook: "https://discord.com/api/webhooks/1/abc"}, NotificationEventPushError)
+	created, err := client.CreateNotification(n)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID != 3 {
+		t.Errorf("Expected notification ID 3, got %d", created.ID)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestNotificationUpdateTestDelete(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/notification/3":    {statusCode: http.StatusNoContent},
+		"/api/notification/test": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "PUT", url: "/api/notification/3"},
+		{method: "POST", url: "/api/notification/test"},
+		{method: "DELETE", url: "/api/notification/3"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	n := NewNotification("Gotify", GotifySettings{Host: "https://gotify.example.com", AppToken: "token"})
+	n.ID = 3
+
+	updated, err := client.UpdateNotification(3, n)
+	if err != nil {
+		t.Fatalf("Expected no error from UpdateNotification, got %v", err)
+	}
+	if updated.Host != "https://gotify.example.com" {
+		t.Errorf("Expected submitted notification to be returned, got %+v", updated)
+	}
+	if err := client.TestNotification(n); err != nil {
+		t.Fatalf("Expected no error from TestNotification, got %v", err)
+	}
+	if err := client.DeleteNotification(3); err != nil {
+		t.Fatalf("Expected no error from DeleteNotification, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestTestNotification_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/notification/test": {statusCode: http.StatusInternalServerError, responseBody: "invalid webhook"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/notification/test"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.TestNotification(NewNotification("Discord", DiscordSettings{})); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
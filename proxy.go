@@ -0,0 +1,165 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ProxyType is the protocol spoken by a proxy
+type ProxyType string
+
+const (
+	ProxyTypeSOCKS5 ProxyType = "SOCKS5"
+	ProxyTypeHTTP   ProxyType = "HTTP"
+)
+
+// Proxy represents a proxy that indexers can be routed through
+type Proxy struct {
+	ID      int       `json:"id,omitempty"`
+	Name    string    `json:"name"`
+	Enabled bool      `json:"enabled"`
+	Type    ProxyType `json:"type"`
+	Addr    string    `json:"addr"` // e.g. socks5://host:1080
+	User    string    `json:"user,omitempty"`
+	Pass    string    `json:"pass,omitempty"`
+	Timeout int       `json:"timeout,omitempty"` // seconds
+}
+
+// GetProxies retrieves all proxies
+func (c *Client) GetProxies() ([]Proxy, error) {
+	if err := c.require("GetProxies", FeatureProxies); err != nil {
+		return nil, err
+	}
+
+	var proxies []Proxy
+	if err := c.doGetJSON("GetProxies", "/api/proxy", &proxies); err != nil {
+		return nil, fmt.Errorf("get proxies error: %w", err)
+	}
+
+	return proxies, nil
+}
+
+// GetProxy retrieves a specific proxy by ID
+func (c *Client) GetProxy(id int64) (*Proxy, error) {
+	if err := c.require("GetProxy", FeatureProxies); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/api/proxy/%d", id)
+	var proxy Proxy
+	if err := c.doGetJSON("GetProxy", endpoint, &proxy); err != nil {
+		return nil, fmt.Errorf("get proxy error: %w", err)
+	}
+
+	return &proxy, nil
+}
+
+// CreateProxy creates a new proxy
+func (c *Client) CreateProxy(proxy *Proxy) (*Proxy, error) {
+	if err := c.require("CreateProxy", FeatureProxies); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proxy: %v", err)
+	}
+
+	respData, err := c.doPost("CreateProxy", "/api/proxy", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("create proxy error: %w", err)
+	}
+
+	createdProxy := *proxy
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &createdProxy); err != nil {
+			return nil, fmt.Errorf("failed to decode created proxy: %v", err)
+		}
+	}
+
+	return &createdProxy, nil
+}
+
+// UpdateProxy updates an existing proxy.
+// When the server does not echo the proxy back, the submitted proxy is returned.
+func (c *Client) UpdateProxy(id int64, proxy *Proxy) (*Proxy, error) {
+	if err := c.require("UpdateProxy", FeatureProxies); err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proxy: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("/api/proxy/%d", id)
+	respData, err := c.doPut("UpdateProxy", endpoint, bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("update proxy error: %w", err)
+	}
+
+	updatedProxy := *proxy
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &updatedProxy); err != nil {
+			return nil, fmt.Errorf("failed to decode updated proxy: %v", err)
+		}
+	}
+
+	return &updatedProxy, nil
+}
+
+// DeleteProxy deletes a proxy by ID
+func (c *Client) DeleteProxy(id int64) error {
+	if err := c.require("DeleteProxy", FeatureProxies); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/api/proxy/%d", id)
+	_, err := c.doDelete("DeleteProxy", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete proxy error: %w", err)
+	}
+
+	return nil
+}
+
+// ToggleProxyEnabled enables or disables a proxy.
+// autobrr has no toggle endpoint for proxies, so the proxy is fetched and saved again.
+func (c *Client) ToggleProxyEnabled(id int64, enabled bool) error {
+	proxy, err := c.GetProxy(id)
+	if err != nil {
+		return err
+	}
+
+	if proxy.Enabled == enabled {
+		return nil
+	}
+	proxy.Enabled = enabled
+
+	if _, err := c.UpdateProxy(id, proxy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TestProxy checks that the server can connect through the given proxy.
+// The proxy does not need to be saved first.
+func (c *Client) TestProxy(proxy *Proxy) error {
+	if err := c.require("TestProxy", FeatureProxies); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(proxy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal proxy: %v", err)
+	}
+
+	_, err = c.doPost("TestProxy", "/api/proxy/test", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("test proxy error: %w", err)
+	}
+
+	return nil
+}
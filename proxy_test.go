@@ -0,0 +1,179 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetProxies(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/proxy": {statusCode: http.StatusOK, responseBody: `[{"id":1,"name":"seedbox","enabled":true,"type":"SOCKS5","addr":"socks5://10.0.0.2:1080","user":"u","pass":"p","timeout":30}]`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/proxy"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	proxies, err := client.GetProxies()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Proxy{ID: 1, Name: "seedbox", Enabled: true, Type: ProxyTypeSOCKS5, Addr: "socks5://10.0.0.2:1080", User: "u", Pass: "p", Timeout: 30}
+	if len(proxies) != 1 || proxies[0] != expected {
+		t.Errorf("Expected [%+v], got %+v", expected, proxies)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestCreateProxy(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/proxy": {statusCode: http.StatusCreated, responseBody: `{"id":2,"name":"http","enabled":true,"type":"HTTP","addr":"http://10.0.0.3:8080"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/proxy"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	proxy, err := client.CreateProxy(&Proxy{Name: "http", Enabled: true, Type: ProxyTypeHTTP, Addr: "http://10.0.0.3:8080"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if proxy.ID != 2 {
+		t.Errorf("Expected proxy ID 2, got %d", proxy.ID)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestToggleProxyEnabled(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"GET /api/proxy/1": {statusCode: http.StatusOK, responseBody: `{"id":1,"name":"seedbox","enabled":true,"type":"SOCKS5","addr":"socks5://10.0.0.2:1080","user":"u","pass":"p"}`},
+		"PUT /api/proxy/1": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/proxy/1"},
+		{method: "PUT", url: "/api/proxy/1"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/proxy/1": func(req *http.Request) {
+			if req.Method != "PUT" {
+				return
+			}
+			var proxy Proxy
+			if err := json.NewDecoder(req.Body).Decode(&proxy); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			// The rest of the proxy must be sent back unchanged
+			if proxy.Enabled || proxy.Addr != "socks5://10.0.0.2:1080" || proxy.Pass != "p" {
+				t.Errorf("Unexpected request body: %+v", proxy)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	if err := client.ToggleProxyEnabled(1, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestToggleProxyEnabled_Unchanged(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/proxy/1": {statusCode: http.StatusOK, responseBody: `{"id":1,"name":"seedbox","enabled":true}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/proxy/1"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	if err := client.ToggleProxyEnabled(1, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// No update is sent when the proxy is already in the requested state
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestProxyTestAndDelete(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/proxy/test": {statusCode: http.StatusNoContent},
+		"/api/proxy/1":    {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/proxy/test"},
+		{method: "DELETE", url: "/api/proxy/1"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	if err := client.TestProxy(&Proxy{Type: ProxyTypeSOCKS5, Addr: "socks5://10.0.0.2:1080"}); err != nil {
+		t.Fatalf("Expected no error from TestProxy, got %v", err)
+	}
+	if err := client.DeleteProxy(1); err != nil {
+		t.Fatalf("Expected no error from DeleteProxy, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestTestProxy_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/proxy/test": {statusCode: http.StatusBadRequest, responseBody: "connection refused"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/proxy/test"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	if err := client.TestProxy(&Proxy{Type: ProxyTypeHTTP, Addr: "http://nowhere:1"}); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
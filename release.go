@@ -0,0 +1,334 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ReleasePushStatus is the outcome of an action run for a release
+type ReleasePushStatus string
+
+const (
+	ReleasePushApproved ReleasePushStatus = "PUSH_APPROVED"
+	ReleasePushRejected ReleasePushStatus = "PUSH_REJECTED"
+	ReleasePushError    ReleasePushStatus = "PUSH_ERROR"
+	ReleasePushPending  ReleasePushStatus = "PENDING"
+)
+
+// ReleaseStats represents the release counts shown on the autobrr dashboard
+type ReleaseStats struct {
+	TotalCount          int `json:"total_count"`
+	FilteredCount       int `json:"filtered_count"`
+	FilterRejectedCount int `json:"filter_rejected_count"`
+	PushApprovedCount   int `json:"push_approved_count"`
+	PushRejectedCount   int `json:"push_rejected_count"`
+	PushErrorCount      int `json:"push_error_count"`
+}
+
+// Release represents a release seen by autobrr
+type Release struct {
+	ID             int64                 `json:"id"`
+	FilterStatus   string                `json:"filter_status"`
+	Rejections     []string              `json:"rejections"`
+	Indexer        ReleaseIndexer        `json:"indexer"`
+	Filter         string                `json:"filter"`
+	FilterID       int                   `json:"filter_id,omitempty"`
+	Protocol       string                `json:"protocol"`
+	Implementation string                `json:"implementation"`
+	Timestamp      string                `json:"timestamp"`
+	InfoURL        string                `json:"info_url,omitempty"`
+	DownloadURL    string                `json:"download_url,omitempty"`
+	GroupID        string                `json:"group_id,omitempty"`
+	TorrentID      string                `json:"torrent_id,omitempty"`
+	Name           string                `json:"name"`
+	Title          string                `json:"title"`
+	Category       string                `json:"category,omitempty"`
+	Season         int                   `json:"season,omitempty"`
+	Episode        int                   `json:"episode,omitempty"`
+	Year           int                   `json:"year,omitempty"`
+	Resolution     string                `json:"resolution,omitempty"`
+	Source         string                `json:"source,omitempty"`
+	Codec          []string              `json:"codec,omitempty"`
+	Container      string                `json:"container,omitempty"`
+	HDR            []string              `json:"hdr,omitempty"`
+	Group          string                `json:"group,omitempty"`
+	Proper         bool                  `json:"proper"`
+	Repack         bool                  `json:"repack"`
+	Website        string                `json:"website,omitempty"`
+	Type           string                `json:"type,omitempty"`
+	Origin         string                `json:"origin,omitempty"`
+	Tags           []string              `json:"tags,omitempty"`
+	Uploader       string                `json:"uploader,omitempty"`
+	Size           uint64                `json:"size,omitempty"`
+	ActionStatus   []ReleaseActionStatus `json:"action_status,omitempty"`
+}
+
+// ReleaseIndexer identifies the indexer a release was announced on.
+// Older autobrr versions send only the identifier as a string.
+type ReleaseIndexer struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Identifier         string `json:"identifier"`
+	IdentifierExternal string `json:"identifier_external,omitempty"`
+}
+
+// UnmarshalJSON accepts both the indexer object and the plain identifier string
+func (ri *ReleaseIndexer) UnmarshalJSON(data []byte) error {
+	var identifier string
+	if err := json.Unmarshal(data, &identifier); err == nil {
+		*ri = ReleaseIndexer{Identifier: identifier}
+		return nil
+	}
+
+	type plain ReleaseIndexer
+	return json.Unmarshal(data, (*plain)(ri))
+}
+
+// ReleaseActionStatus is the result of running a filter action for a release
+type ReleaseActionStatus struct {
+	ID         int64             `json:"id"`
+	Status     ReleasePushStatus `json:"status"`
+	Action     string            `json:"action"`
+	ActionID   int64             `json:"action_id"`
+	Type       string            `json:"type"`
+	Client     string            `json:"client,omitempty"`
+	Filter     string            `json:"filter,omitempty"`
+	FilterID   int64             `json:"filter_id,omitempty"`
+	Rejections []string          `json:"rejections"`
+	Timestamp  string            `json:"timestamp"`
+	ReleaseID  int64             `json:"release_id"`
+}
+
+// releaseListResponse is the paginated envelope used by the release list endpoints
+type releaseListResponse struct {
+	Data       []Release `json:"data"`
+	Count      int       `json:"count"`
+	NextCursor int64     `json:"next_cursor"`
+}
+
+// GetReleaseStats retrieves the release counts shown on the dashboard
+func (c *Client) GetReleaseStats() (*ReleaseStats, error) {
+	var stats ReleaseStats
+	if err := c.doGetJSON("GetReleaseStats", "/api/release/stats", &stats); err != nil {
+		return nil, fmt.Errorf("get release stats error: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetRecentReleases retrieves the most recent releases shown on the dashboard
+func (c *Client) GetRecentReleases() ([]Release, error) {
+	var response releaseListResponse
+	if err := c.doGetJSON("GetRecentReleases", "/api/release/recent", &response); err != nil {
+		return nil, fmt.Errorf("get recent releases error: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+// DeleteReleasesOptions selects the releases removed by DeleteReleases.
+// Criteria are combined; releases matching all of them are deleted.
+type DeleteReleasesOptions struct {
+	// OlderThan only deletes releases older than this. autobrr works in whole
+	// hours, so the duration is truncated to hours and must be at least one hour
+	// when set. Zero deletes releases of any age.
+	OlderThan time.Duration
+
+	// Indexers only deletes releases from these indexer identifiers
+	Indexers []string
+
+	// ReleaseStatuses only deletes releases with these action statuses
+	ReleaseStatuses []ReleasePushStatus
+
+	// All deletes the entire release history. It must be set explicitly and
+	// cannot be combined with other criteria.
+	All bool
+}
+
+// DeleteReleases deletes releases from the release history. At least one
+// criterion, or All, must be set.
+func (c *Client) DeleteReleases(opts DeleteReleasesOptions) error {
+	hasCriteria := opts.OlderThan != 0 || len(opts.Indexers) > 0 || len(opts.ReleaseStatuses) > 0
+	switch {
+	case opts.All && hasCriteria:
+		return fmt.Errorf("delete releases error: all cannot be combined with other criteria")
+	case !opts.All && !hasCriteria:
+		return fmt.Errorf("delete releases error: no criteria set; set All to delete every release")
+	}
+
+	query := url.Values{}
+	if opts.OlderThan != 0 {
+		hours := int(opts.OlderThan / time.Hour)
+		if hours < 1 {
+			return fmt.Errorf("delete releases error: older than must be at least one hour, got %v", opts.OlderThan)
+		}
+		query.Set("olderThan", strconv.Itoa(hours))
+	}
+	for _, indexer := range opts.Indexers {
+		query.Add("indexer", indexer)
+	}
+	for _, status := range opts.ReleaseStatuses {
+		query.Add("releaseStatus", string(status))
+	}
+
+	endpoint := "/api/release"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	_, err := c.doDelete("DeleteReleases", endpoint)
+	if err != nil {
+		return fmt.Errorf("delete releases error: %w", err)
+	}
+
+	return nil
+}
+
+// RetryReleaseAction runs a filter action again for a release, e.g. after the
+// download client was unavailable. actionStatusID is the ID of the
+// ReleaseActionStatus to retry, as listed in Release.ActionStatus.
+func (c *Client) RetryReleaseAction(releaseID, actionStatusID int64) error {
+	endpoint := fmt.Sprintf("/api/release/%d/actions/%d/retry", releaseID, actionStatusID)
+	_, err := c.doPost("RetryReleaseAction", endpoint, nil, "")
+	if err != nil {
+		return fmt.Errorf("retry release action error: %w", err)
+	}
+
+	return nil
+}
+
+// ProcessReleaseRequest describes a release to push through autobrr's pipeline
+// as if it had been announced on IRC
+type ProcessReleaseRequest struct {
+	// IndexerIdentifiers are the indexers the announce is processed for, e.g. "btn".
+	// Each indexer parses the announce with its own IRC definition.
+	IndexerIdentifiers []string
+
+	// AnnounceLines are the raw announce lines, as the indexer's announce bot would send them.
+	// Multi-line announces need every line in order.
+	AnnounceLines []string
+}
+
+// ProcessReleaseResult is the outcome of processing a release for one indexer
+type ProcessReleaseResult struct {
+	IndexerIdentifier string
+	// Response is the response body reported by the server, usually empty
+	Response string
+	Err      error
+}
+
+// processReleaseBody is the request body of the release process endpoint
+type processReleaseBody struct {
+	IndexerIdentifier string   `json:"indexer_identifier"`
+	AnnounceLines     []string `json:"announce_lines"`
+}
+
+// ProcessRelease injects an announce into autobrr's release pipeline for each
+// target indexer, running it through filters and actions without waiting for an
+// IRC announce. The server only accepts raw announce lines, which the indexer's
+// definition parses into release fields. A result is returned for every indexer;
+// the error is non-nil if processing failed for any of them.
+func (c *Client) ProcessRelease(req ProcessReleaseRequest) ([]ProcessReleaseResult, error) {
+	if len(req.IndexerIdentifiers) == 0 {
+		return nil, fmt.Errorf("process release error: no indexer identifiers given")
+	}
+	if len(req.AnnounceLines) == 0 {
+		return nil, fmt.Errorf("process release error: no announce lines given")
+	}
+	if err := c.require("ProcessRelease", FeatureReleaseProcess); err != nil {
+		return nil, err
+	}
+
+	results := make([]ProcessReleaseResult, 0, len(req.IndexerIdentifiers))
+	failed := 0
+	for _, identifier := range req.IndexerIdentifiers {
+		result := ProcessReleaseResult{IndexerIdentifier: identifier}
+
+		jsonData, err := json.Marshal(processReleaseBody{
+			IndexerIdentifier: identifier,
+			AnnounceLines:     req.AnnounceLines,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal process release request: %v", err)
+		}
+
+		respData, err := c.doPost("ProcessRelease", "/api/release/process", bytes.NewReader(jsonData), "application/json")
+		if err != nil {
+			result.Err = fmt.Errorf("process release error: %w", err)
+			failed++
+		}
+		result.Response = string(respData)
+
+		results = append(results, result)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("process release error: %d of %d indexers failed", failed, len(results))
+	}
+
+	return results, nil
+}
+
+// FilterDownloadStats is the download rollup of a single filter
+type FilterDownloadStats struct {
+	FilterID   int    `json:"filter_id"`
+	FilterName string `json:"filter_name"`
+	Enabled    bool   `json:"enabled"`
+	Downloads
+}
+
+// GetFilterDownloadStats retrieves the download counts of every filter, sorted by
+// WeekCount and then TotalCount, highest first. Filters whose list entry carries no
+// statistics are fetched individually.
+func (c *Client) GetFilterDownloadStats() ([]FilterDownloadStats, error) {
+	filters, err := c.GetFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range filters {
+		if filters[i].Downloads != nil {
+			continue
+		}
+
+		filter, err := c.GetFilter(int64(filters[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		filters[i].Downloads = filter.Downloads
+	}
+
+	return RollupFilterDownloads(filters), nil
+}
+
+// RollupFilterDownloads builds per-filter download statistics from Filter.Downloads,
+// sorted by WeekCount and then TotalCount, highest first. Filters without
+// statistics are reported with zero counts.
+func RollupFilterDownloads(filters []Filter) []FilterDownloadStats {
+	stats := make([]FilterDownloadStats, 0, len(filters))
+	for _, f := range filters {
+		s := FilterDownloadStats{
+			FilterID:   f.ID,
+			FilterName: f.Name,
+			Enabled:    f.Enabled,
+		}
+		if f.Downloads != nil {
+			s.Downloads = *f.Downloads
+		}
+		stats = append(stats, s)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].WeekCount != stats[j].WeekCount {
+			return stats[i].WeekCount > stats[j].WeekCount
+		}
+		return stats[i].TotalCount > stats[j].TotalCount
+	})
+
+	return stats
+}
@@ -0,0 +1,340 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetReleaseStats(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release/stats": {statusCode: http.StatusOK, responseBody: `{"total_count":100,"filtered_count":40,"filter_rejected_count":60,"push_approved_count":30,"push_rejected_count":8,"push_error_count":2}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/release/stats"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stats, err := client.GetReleaseStats()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := ReleaseStats{TotalCount: 100, FilteredCount: 40, FilterRejectedCount: 60, PushApprovedCount: 30, PushRejectedCount: 8, PushErrorCount: 2}
+	if *stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *stats)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestGetRecentReleases(t *testing.T) {
+	// The first release uses the indexer object of current versions, the second the plain identifier of older ones
+	endpointResponses := map[string]mockResponse{
+		"/api/release/recent": {statusCode: http.StatusOK, responseBody: `{"data":[
+			{"id":2,"filter_status":"FILTER_APPROVED","indexer":{"id":1,"name":"BroadcasTheNet","identifier":"btn"},"filter":"TV","name":"Show.S01E01.1080p","action_status":[{"id":5,"status":"PUSH_APPROVED","action":"qbit","action_id":3}]},
+			{"id":1,"filter_status":"FILTER_REJECTED","indexer":"ptp","name":"Movie.2024.2160p"}
+		],"count":2,"next_cursor":0}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/release/recent"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	releases, err := client.GetRecentReleases()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(releases) != 2 {
+		t.Fatalf("Expected 2 releases, got %d", len(releases))
+	}
+	if releases[0].Indexer.Identifier != "btn" || releases[0].Indexer.Name != "BroadcasTheNet" {
+		t.Errorf("Unexpected indexer: %+v", releases[0].Indexer)
+	}
+	if len(releases[0].ActionStatus) != 1 || releases[0].ActionStatus[0].Status != ReleasePushApproved {
+		t.Errorf("Unexpected action status: %+v", releases[0].ActionStatus)
+	}
+	if releases[1].Indexer.Identifier != "ptp" {
+		t.Errorf("Expected identifier 'ptp', got %+v", releases[1].Indexer)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestGetFilterDownloadStats(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/filters":   {statusCode: http.StatusOK, responseBody: `[{"id":1,"name":"TV","enabled":true,"downloads":{"week_count":2,"total_count":50}},{"id":2,"name":"Movies"}]`},
+		"/api/filters/2": {statusCode: http.StatusOK, responseBody: `{"id":2,"name":"Movies","downloads":{"hour_count":1,"day_count":3,"week_count":9,"month_count":20,"total_count":40}}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/filters"},
+		{method: "GET", url: "/api/filters/2"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stats, err := client.GetFilterDownloadStats()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(stats))
+	}
+	if stats[0].FilterName != "Movies" || stats[0].WeekCount != 9 || stats[0].HourCount != 1 {
+		t.Errorf("Expected Movies first with week count 9, got %+v", stats[0])
+	}
+	if stats[1].FilterName != "TV" || !stats[1].Enabled || stats[1].TotalCount != 50 {
+		t.Errorf("Unexpected second entry: %+v", stats[1])
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestRollupFilterDownloads(t *testing.T) {
+	stats := RollupFilterDownloads([]Filter{
+		{ID: 1, Name: "Idle"},
+		{ID: 2, Name: "Busy", Downloads: &Downloads{WeekCount: 5, TotalCount: 10}},
+		{ID: 3, Name: "Old favourite", Downloads: &Downloads{WeekCount: 5, TotalCount: 100}},
+	})
+
+	names := []string{stats[0].FilterName, stats[1].FilterName, stats[2].FilterName}
+	if names[0] != "Old favourite" || names[1] != "Busy" || names[2] != "Idle" {
+		t.Errorf("Unexpected order: %v", names)
+	}
+}
+
+func TestDeleteReleases(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "DELETE", url: "/api/release"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/release": func(req *http.Request) {
+			query := req.URL.Query()
+			if query.Get("olderThan") != "720" {
+				t.Errorf("Expected olderThan=720, got %q", query.Get("olderThan"))
+			}
+			if indexers := query["indexer"]; len(indexers) != 2 || indexers[0] != "btn" || indexers[1] != "ptp" {
+				t.Errorf("Expected indexers [btn ptp], got %v", indexers)
+			}
+			if statuses := query["releaseStatus"]; len(statuses) != 1 || statuses[0] != "PUSH_REJECTED" {
+				t.Errorf("Expected releaseStatus [PUSH_REJECTED], got %v", statuses)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err = client.DeleteReleases(DeleteReleasesOptions{
+		OlderThan:       30 * 24 * time.Hour,
+		Indexers:        []string{"btn", "ptp"},
+		ReleaseStatuses: []ReleasePushStatus{ReleasePushRejected},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestDeleteReleases_InvalidOlderThan(t *testing.T) {
+	client, mockTransport, err := newMockClient(nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.DeleteReleases(DeleteReleasesOptions{OlderThan: 30 * time.Minute}); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	if mockTransport.requestIndex != 0 {
+		t.Errorf("Expected no request to be made")
+	}
+}
+
+func TestDeleteReleases_NoCriteria(t *testing.T) {
+	client, mockTransport, err := newMockClient(nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.DeleteReleases(DeleteReleasesOptions{}); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if err := client.DeleteReleases(DeleteReleasesOptions{All: true, Indexers: []string{"btn"}}); err == nil {
+		t.Fatal("Expected error for All combined with criteria, got none")
+	}
+
+	if mockTransport.requestIndex != 0 {
+		t.Errorf("Expected no request to be made")
+	}
+}
+
+func TestDeleteReleases_All(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "DELETE", url: "/api/release"},
+	}
+
+	customHandler := map[string]func(*http.Request){
+		"/api/release": func(req *http.Request) {
+			if req.URL.RawQuery != "" {
+				t.Errorf("Expected no query, got %q", req.URL.RawQuery)
+			}
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.DeleteReleases(DeleteReleasesOptions{All: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestRetryReleaseAction(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release/12/actions/34/retry": {statusCode: http.StatusOK},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/release/12/actions/34/retry"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := client.RetryReleaseAction(12, 34); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestProcessRelease(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release/process": {statusCode: http.StatusNoContent},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/release/process"},
+		{method: "POST", url: "/api/release/process"},
+	}
+
+	var identifiers []string
+	customHandler := map[string]func(*http.Request){
+		"/api/release/process": func(req *http.Request) {
+			var body struct {
+				IndexerIdentifier string   `json:"indexer_identifier"`
+				AnnounceLines     []string `json:"announce_lines"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if len(body.AnnounceLines) != 1 || body.AnnounceLines[0] != "New Torrent: Show.S01E01.1080p.WEB-DL" {
+				t.Errorf("Unexpected announce lines: %v", body.AnnounceLines)
+			}
+			identifiers = append(identifiers, body.IndexerIdentifier)
+		},
+	}
+
+	client, mockTransport, err := newMockClientWithHandler(endpointResponses, expectedRequests, customHandler)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	results, err := client.ProcessRelease(ProcessReleaseRequest{
+		IndexerIdentifiers: []string{"btn", "ptp"},
+		AnnounceLines:      []string{"New Torrent: Show.S01E01.1080p.WEB-DL"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(results) != 2 || results[0].IndexerIdentifier != "btn" || results[1].IndexerIdentifier != "ptp" || results[0].Err != nil {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+	if len(identifiers) != 2 || identifiers[0] != "btn" || identifiers[1] != "ptp" {
+		t.Errorf("Expected one request per indexer, got %v", identifiers)
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestProcessRelease_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release/process": {statusCode: http.StatusBadRequest, responseBody: "indexer not found"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/release/process"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.setServerVersion("v1.50.0")
+
+	results, err := client.ProcessRelease(ProcessReleaseRequest{
+		IndexerIdentifiers: []string{"unknown"},
+		AnnounceLines:      []string{"line"},
+	})
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if len(results) != 1 || results[0].Err == nil {
+		t.Errorf("Expected failed result for the indexer, got %+v", results)
+	}
+
+	if _, err := client.ProcessRelease(ProcessReleaseRequest{AnnounceLines: []string{"line"}}); err == nil {
+		t.Error("Expected error without indexer identifiers, got none")
+	}
+}
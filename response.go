@@ -0,0 +1,131 @@
+package autobrr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultMaxResponseSize is the largest response body read by a client unless
+// configured otherwise with WithMaxResponseSize
+const DefaultMaxResponseSize int64 = 64 << 20
+
+// ErrResponseTooLarge is matched by errors.Is for every *ResponseTooLargeError
+var ErrResponseTooLarge = errors.New("autobrr: response too large")
+
+// ResponseTooLargeError is returned when a response body exceeds the maximum
+// response size of the client
+type ResponseTooLargeError struct {
+	Operation string
+	Limit     int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("autobrr: %s response exceeds the maximum size of %d bytes", e.Operation, e.Limit)
+}
+
+// Is makes errors.Is(err, ErrResponseTooLarge) true for a *ResponseTooLargeError
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// WithMaxResponseSize limits the size of the response bodies read by the client,
+// DefaultMaxResponseSize by default. A limit of zero or less disables the check.
+// Bodies opened with OpenRaw or DownloadLogFile are not limited.
+func WithMaxResponseSize(limit int64) Option {
+	return func(c *Client) {
+		c.maxResponseSize = limit
+	}
+}
+
+// OpenRaw sends a GET request to endpoint, e.g. "/api/release?limit=500", and
+// returns the response body unread so it can be streamed. The caller must close
+// it. Non-2xx responses are returned as errors.
+func (c *Client) OpenRaw(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	resp, err := c.openResponse(ctx, "OpenRaw", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open raw error: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+// doGetJSON performs a GET request and decodes the JSON response into v while
+// it is read, instead of buffering the whole body first. Every JSON GET uses it,
+// except:
+//   - GetFilters and GetFilter with WithFilterCache, as the cache keeps the raw body
+//   - WhoAmI, as the server may answer with an empty body
+//   - GetFeedLatestRun, whose response is plain text
+//
+// Those are read with doGet and remain subject to the maximum response size.
+func (c *Client) doGetJSON(op, endpoint string, v interface{}) (err error) {
+	start := time.Now()
+	statusCode := 0
+	if c.metrics != nil {
+		defer func() {
+			c.metrics.ObserveRequest(op, statusCode, err, time.Since(start))
+		}()
+	}
+
+	resp, err := c.openResponse(context.Background(), op, endpoint, nil)
+	if err != nil {
+		var statusErr *statusError
+		if errors.As(err, &statusErr) {
+			statusCode = statusErr.StatusCode
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	statusCode = resp.StatusCode
+
+	if err := json.NewDecoder(c.limitBody(op, resp.Body)).Decode(v); err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return nil
+}
+
+// limitBody wraps a response body so reading past the maximum response size fails
+func (c *Client) limitBody(op string, body io.Reader) io.Reader {
+	if c.maxResponseSize <= 0 {
+		return body
+	}
+
+	return &limitedBody{r: body, op: op, limit: c.maxResponseSize, remaining: c.maxResponseSize}
+}
+
+// limitedBody reads up to limit bytes and then fails with a *ResponseTooLargeError
+// if the body has more
+type limitedBody struct {
+	r         io.Reader
+	op        string
+	limit     int64
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// A body of exactly limit bytes is fine; only fail when more follows
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseTooLargeError{Operation: l.op, Limit: l.limit}
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
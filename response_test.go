@@ -0,0 +1,154 @@
+package autobrr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseSize_Decoded(t *testing.T) {
+	body := `[{"id":1,"name":"` + strings.Repeat("x", 100) + `"}]`
+	endpointResponses := map[string]mockResponse{
+		"/api/filters": {statusCode: http.StatusOK, responseBody: body},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/filters"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	WithMaxResponseSize(64)(client)
+
+	_, err = client.GetFilters()
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Operation != "GetFilters" || tooLarge.Limit != 64 {
+		t.Errorf("Unexpected error: %#v", tooLarge)
+	}
+}
+
+func TestMaxResponseSize_Buffered(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"POST /api/filters": {statusCode: http.StatusCreated, responseBody: `{"id":1,"name":"` + strings.Repeat("x", 100) + `"}`},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "POST", url: "/api/filters"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	WithMaxResponseSize(64)(client)
+
+	_, err = client.CreateFilter(&Filter{Name: "Test"})
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestMaxResponseSize_AtLimit(t *testing.T) {
+	body := `[{"id":1,"name":"Test"}]`
+	endpointResponses := map[string]mockResponse{
+		"/api/filters": {statusCode: http.StatusOK, responseBody: body},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/filters"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	WithMaxResponseSize(int64(len(body)))(client)
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filters) != 1 || filters[0].Name != "Test" {
+		t.Errorf("Unexpected filters: %+v", filters)
+	}
+}
+
+func TestLimitedBody(t *testing.T) {
+	client := &Client{maxResponseSize: 4}
+
+	data, err := io.ReadAll(client.limitBody("Test", strings.NewReader("abcd")))
+	if err != nil || string(data) != "abcd" {
+		t.Errorf("Expected the full body at the limit, got %q and %v", data, err)
+	}
+
+	if _, err := io.ReadAll(client.limitBody("Test", strings.NewReader("abcde"))); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Expected ErrResponseTooLarge, got %v", err)
+	}
+
+	// A limit of zero disables the check
+	client.maxResponseSize = 0
+	if data, err := io.ReadAll(client.limitBody("Test", strings.NewReader("abcde"))); err != nil || len(data) != 5 {
+		t.Errorf("Expected no limit, got %q and %v", data, err)
+	}
+}
+
+func TestOpenRaw(t *testing.T) {
+	body := `{"data":[],"next_cursor":0}`
+	endpointResponses := map[string]mockResponse{
+		"/api/release": {statusCode: http.StatusOK, responseBody: body},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/release"},
+	}
+
+	client, mockTransport, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Raw bodies are not limited
+	WithMaxResponseSize(4)(client)
+
+	rc, err := client.OpenRaw(context.Background(), "/api/release?limit=500")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer rc.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, rc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != body {
+		t.Errorf("Expected the raw body, got %q", buf.String())
+	}
+
+	// Check the request made
+	if mockTransport.requestIndex != len(mockTransport.expectedRequests) {
+		t.Errorf("Not all expected requests were made")
+	}
+}
+
+func TestOpenRaw_Error(t *testing.T) {
+	endpointResponses := map[string]mockResponse{
+		"/api/release": {statusCode: http.StatusInternalServerError, responseBody: "internal error"},
+	}
+	expectedRequests := []expectedRequest{
+		{method: "GET", url: "/api/release"},
+	}
+
+	client, _, err := newMockClient(endpointResponses, expectedRequests)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.OpenRaw(context.Background(), "/api/release"); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("Expected error with status code, got %v", err)
+	}
+}
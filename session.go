@@ -0,0 +1,200 @@
+package autobrr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+)
+
+// Session describes the user an authenticated session belongs to
+type Session struct {
+	Username   string `json:"username"`
+	AuthMethod string `json:"auth_method,omitempty"`
+}
+
+// sessionAuth holds the credentials and cookies of username/password authentication.
+// The cookies live in a jar owned by the client, so a shared http.Client is not affected.
+type sessionAuth struct {
+	mu       sync.Mutex
+	jar      *cookiejar.Jar
+	username string
+	password string
+
+	// login serializes logins, so concurrent 401s log in only once
+	login sync.Mutex
+	// generation is incremented by every successful login
+	generation uint64
+}
+
+func newSessionAuth() *sessionAuth {
+	// cookiejar.New only fails for invalid options
+	jar, _ := cookiejar.New(nil)
+	return &sessionAuth{jar: jar}
+}
+
+// credentials returns the configured username and password
+func (s *sessionAuth) credentials() (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.username, s.password
+}
+
+// setCredentials replaces the username and password used to log in
+func (s *sessionAuth) setCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.username = username
+	s.password = password
+}
+
+// reset forgets the credentials and the session cookie
+func (s *sessionAuth) reset() {
+	jar, _ := cookiejar.New(nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.username = ""
+	s.password = ""
+	s.jar = jar
+}
+
+// addCookies adds the session cookies for the request URL
+func (s *sessionAuth) addCookies(req *http.Request) {
+	s.mu.Lock()
+	jar := s.jar
+	s.mu.Unlock()
+
+	for _, cookie := range jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+}
+
+// storeCookies stores cookies set by a response
+func (s *sessionAuth) storeCookies(u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	jar := s.jar
+	s.mu.Unlock()
+
+	jar.SetCookies(u, cookies)
+}
+
+// WithSessionAuth authenticates with a username and password instead of, or in
+// addition to, an API key. The client logs in on the first 401 response and
+// again whenever the session expires. Pass an empty API key to NewClientWithOptions
+// to use session authentication only.
+func WithSessionAuth(username, password string) Option {
+	return func(c *Client) {
+		c.session.setCredentials(username, password)
+	}
+}
+
+// Login logs in with a username and password and keeps the session cookie.
+// The credentials are remembered to log in again when the session expires.
+func (c *Client) Login(username, password string) error {
+	c.session.setCredentials(username, password)
+
+	c.session.login.Lock()
+	defer c.session.login.Unlock()
+
+	return c.login(username, password)
+}
+
+// login posts the credentials; the session cookie is stored by roundTrip.
+// The caller holds c.session.login.
+func (c *Client) login(username, password string) error {
+	data := map[string]string{"username": username, "password": password}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal login data: %v", err)
+	}
+
+	_, err = c.doPost("Login", "/api/auth/login", bytes.NewReader(jsonData), "application/json")
+	if err != nil {
+		return fmt.Errorf("login error: %w", err)
+	}
+
+	c.session.mu.Lock()
+	c.session.generation++
+	c.session.mu.Unlock()
+
+	return nil
+}
+
+// Logout ends the session and forgets the credentials, so the client no longer
+// logs in again on its own
+func (c *Client) Logout() error {
+	_, err := c.doPost("Logout", "/api/auth/logout", nil, "")
+	c.session.reset()
+	if err != nil {
+		return fmt.Errorf("logout error: %w", err)
+	}
+
+	return nil
+}
+
+// WhoAmI validates the current session and returns the user it belongs to.
+// Older autobrr versions only confirm the session, in which case Username is the
+// configured one.
+func (c *Client) WhoAmI() (*Session, error) {
+	respData, err := c.doGet("WhoAmI", "/api/auth/validate")
+	if err != nil {
+		return nil, fmt.Errorf("validate session error: %w", err)
+	}
+
+	var session Session
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &session); err != nil {
+			return nil, fmt.Errorf("failed to decode session: %v", err)
+		}
+	}
+	if session.Username == "" {
+		session.Username, _ = c.session.credentials()
+	}
+
+	return &session, nil
+}
+
+// canReauthenticate reports whether a 401 response to op should be answered by
+// logging in again
+func (c *Client) canReauthenticate(op string) bool {
+	if op == "Login" || op == "Logout" {
+		return false
+	}
+
+	username, _ := c.session.credentials()
+	return username != ""
+}
+
+// reauthenticate logs in again after a 401 response. Concurrent callers wait for
+// a single login.
+func (c *Client) reauthenticate() error {
+	c.session.mu.Lock()
+	generation := c.session.generation
+	c.session.mu.Unlock()
+
+	c.session.login.Lock()
+	defer c.session.login.Unlock()
+
+	c.session.mu.Lock()
+	loggedIn := c.session.generation != generation
+	c.session.mu.Unlock()
+	if loggedIn {
+		// Another request logged in while we waited
+		return nil
+	}
+
+	username, password := c.session.credentials()
+	return c.login(username, password)
+}
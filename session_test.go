@@ -0,0 +1,161 @@
+package autobrr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// sessionServer is a minimal autobrr auth backend. Sessions can be expired to
+// force the client to log in again.
+type sessionServer struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+	logins   int
+	requests []string
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if r.Header.Get("X-API-Token") != "" {
+		http.Error(w, "unexpected api key", http.StatusBadRequest)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/auth/login":
+		var creds map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds["username"] != "admin" || creds["password"] != "secret" {
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		s.logins++
+		id := fmt.Sprintf("session-%d", s.logins)
+		s.sessions[id] = true
+		http.SetCookie(w, &http.Cookie{Name: "user_session", Value: id, Path: "/", HttpOnly: true})
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	cookie, err := r.Cookie("user_session")
+	if err != nil || !s.sessions[cookie.Value] {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/auth/logout":
+		delete(s.sessions, cookie.Value)
+		w.WriteHeader(http.StatusNoContent)
+	case "/api/auth/validate":
+		fmt.Fprint(w, `{"username":"admin","auth_method":"password"}`)
+	case "/api/filters":
+		body, _ := json.Marshal([]Filter{{ID: 1, Name: "TV"}})
+		w.Write(body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// expireSessions invalidates every session, as a server restart would
+func (s *sessionServer) expireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]bool{}
+}
+
+func newSessionTestClient(t *testing.T, opts ...Option) (*Client, *sessionServer) {
+	backend := &sessionServer{sessions: map[string]bool{}}
+	srv := httptest.NewServer(backend)
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	opts = append([]Option{WithHTTPClient(srv.Client())}, opts...)
+	client, err := NewClientWithOptions("", u.Hostname(), u.Port(), opts...)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client, backend
+}
+
+func TestSessionAuth_LoginOn401(t *testing.T) {
+	client, backend := newSessionTestClient(t, WithSessionAuth("admin", "secret"))
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filters) != 1 {
+		t.Errorf("Expected 1 filter, got %d", len(filters))
+	}
+
+	// The session is reused until it expires
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	backend.expireSessions()
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error after session expiry, got %v", err)
+	}
+
+	expected := []string{
+		"GET /api/filters", "POST /api/auth/login", "GET /api/filters",
+		"GET /api/filters",
+		"GET /api/filters", "POST /api/auth/login", "GET /api/filters",
+	}
+	if fmt.Sprint(backend.requests) != fmt.Sprint(expected) {
+		t.Errorf("Expected requests %v, got %v", expected, backend.requests)
+	}
+}
+
+func TestSessionAuth_InvalidCredentials(t *testing.T) {
+	client, backend := newSessionTestClient(t, WithSessionAuth("admin", "wrong"))
+
+	if _, err := client.GetFilters(); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+
+	// One failed login, no retry loop
+	if len(backend.requests) != 2 {
+		t.Errorf("Expected 2 requests, got %v", backend.requests)
+	}
+}
+
+func TestLoginWhoAmILogout(t *testing.T) {
+	client, backend := newSessionTestClient(t)
+
+	if err := client.Login("admin", "secret"); err != nil {
+		t.Fatalf("Expected no error from Login, got %v", err)
+	}
+
+	session, err := client.WhoAmI()
+	if err != nil {
+		t.Fatalf("Expected no error from WhoAmI, got %v", err)
+	}
+	if session.Username != "admin" || session.AuthMethod != "password" {
+		t.Errorf("Unexpected session: %+v", session)
+	}
+
+	if err := client.Logout(); err != nil {
+		t.Fatalf("Expected no error from Logout, got %v", err)
+	}
+
+	// The credentials are forgotten, so the client does not log in again
+	if _, err := client.WhoAmI(); err == nil {
+		t.Fatal("Expected error after logout, got none")
+	}
+	if backend.logins != 1 {
+		t.Errorf("Expected 1 login, got %d", backend.logins)
+	}
+}
@@ -0,0 +1,267 @@
+package autobrr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogStream is the name of the server-sent event stream carrying autobrr's logs
+const LogStream = "logs"
+
+// maxEventSize is the largest server-sent event line accepted
+const maxEventSize = 1 << 20
+
+// Event is a single server-sent event
+type Event struct {
+	ID    string
+	Event string
+	Data  []byte
+	// Retry is the reconnection delay requested by the server, zero if none
+	Retry time.Duration
+}
+
+// StreamOption configures StreamEvents and StreamLogs
+type StreamOption func(*streamConfig)
+
+// streamConfig holds the settings applied by StreamOption
+type streamConfig struct {
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	onError    func(error)
+}
+
+// Default reconnection delays of StreamEvents
+const (
+	defaultStreamMinBackoff = time.Second
+	defaultStreamMaxBackoff = 30 * time.Second
+)
+
+// WithStreamBackoff sets the delay before the first reconnection attempt and the
+// maximum it doubles up to. The defaults are one and thirty seconds, used when
+// min or max is zero or negative; max is raised to min if it is smaller.
+// A retry interval sent by the server replaces min.
+func WithStreamBackoff(min, max time.Duration) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.minBackoff = min
+		cfg.maxBackoff = max
+	}
+}
+
+// newStreamConfig applies opts to the defaults. The backoff is kept positive so
+// reconnection attempts never run without delay.
+func newStreamConfig(opts []StreamOption) streamConfig {
+	cfg := streamConfig{
+		minBackoff: defaultStreamMinBackoff,
+		maxBackoff: defaultStreamMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.minBackoff <= 0 {
+		cfg.minBackoff = defaultStreamMinBackoff
+	}
+	if cfg.maxBackoff <= 0 {
+		cfg.maxBackoff = defaultStreamMaxBackoff
+	}
+	if cfg.maxBackoff < cfg.minBackoff {
+		cfg.maxBackoff = cfg.minBackoff
+	}
+
+	return cfg
+}
+
+// WithStreamErrorHandler sets a function called with every error that causes a
+// reconnection, including the stream being closed by the server
+func WithStreamErrorHandler(fn func(error)) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.onError = fn
+	}
+}
+
+// StreamEvents subscribes to a server-sent event stream of autobrr's /api/events
+// endpoint, such as LogStream. The initial connection is made before returning
+// so that a bad API key or unreachable server is reported immediately.
+// Afterwards the stream reconnects with exponential backoff, resuming from the
+// last received event ID, until ctx is done. The channel is closed when ctx is done.
+//
+// The http.Client used by the client must not have a Timeout, as it would cut
+// the stream off.
+func (c *Client) StreamEvents(ctx context.Context, stream string, opts ...StreamOption) (<-chan Event, error) {
+	cfg := newStreamConfig(opts)
+
+	endpoint := "/api/events?stream=" + url.QueryEscape(stream)
+
+	resp, err := c.openStream(ctx, endpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("stream events error: %w", err)
+	}
+
+	events := make(chan Event)
+	go c.runStream(ctx, endpoint, resp, events, cfg)
+
+	return events, nil
+}
+
+// StreamLogs subscribes to autobrr's live log stream and decodes each event into
+// a LogEntry. It reconnects like StreamEvents.
+func (c *Client) StreamLogs(ctx context.Context, opts ...StreamOption) (<-chan LogEntry, error) {
+	events, err := c.StreamEvents(ctx, LogStream, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan LogEntry)
+	go func() {
+		defer close(entries)
+
+		for event := range events {
+			entry, ok := parseJSONLogEntry(event.Data)
+			if !ok {
+				entry = LogEntry{Message: string(event.Data)}
+			}
+
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				// Drain so the event goroutine can observe ctx and exit
+				for range events {
+				}
+				return
+			}
+		}
+	}()
+
+	return entries, nil
+}
+
+// openStream connects to an event stream endpoint
+func (c *Client) openStream(ctx context.Context, endpoint, lastEventID string) (*http.Response, error) {
+	header := http.Header{
+		"Accept":        {"text/event-stream"},
+		"Cache-Control": {"no-cache"},
+	}
+	if lastEventID != "" {
+		header.Set("Last-Event-ID", lastEventID)
+	}
+
+	return c.openResponse(ctx, "StreamEvents", endpoint, header)
+}
+
+// runStream delivers events from resp and reconnects until ctx is done
+func (c *Client) runStream(ctx context.Context, endpoint string, resp *http.Response, events chan<- Event, cfg streamConfig) {
+	defer close(events)
+
+	var lastEventID string
+	for {
+		retry, err := readEvents(ctx, resp.Body, events, &lastEventID)
+		resp.Body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("stream closed by server")
+		}
+		if cfg.onError != nil {
+			cfg.onError(err)
+		}
+
+		backoff := cfg.minBackoff
+		if retry > 0 {
+			backoff = retry
+		}
+
+		for {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			resp, err = c.openStream(ctx, endpoint, lastEventID)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if cfg.onError != nil {
+				cfg.onError(fmt.Errorf("stream reconnect error: %w", err))
+			}
+
+			backoff *= 2
+			if backoff > cfg.maxBackoff {
+				backoff = cfg.maxBackoff
+			}
+		}
+	}
+}
+
+// readEvents parses server-sent events from r and sends them on events until r
+// ends. It tracks the last event ID and returns the last retry interval sent.
+func readEvents(ctx context.Context, r io.Reader, events chan<- Event, lastEventID *string) (time.Duration, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventSize)
+
+	var retry time.Duration
+	var event Event
+	var data bytes.Buffer
+	hasData := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the event
+		if line == "" {
+			if hasData {
+				event.Data = bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+				event.ID = *lastEventID
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return retry, ctx.Err()
+				}
+			}
+			event = Event{}
+			data = bytes.Buffer{}
+			hasData = false
+			continue
+		}
+
+		// Lines starting with a colon are comments, used as keep-alives
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				*lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				retry = time.Duration(ms) * time.Millisecond
+				event.Retry = retry
+			}
+		}
+	}
+
+	return retry, scanner.Err()
+}
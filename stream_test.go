@@ -0,0 +1,166 @@
+package autobrr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newStreamTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(srv.URL)
+	client, err := NewClient("test-api-key", u.Hostname(), u.Port(), srv.Client())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return client
+}
+
+func TestStreamEvents_Reconnect(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	connections := 0
+
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/events" || r.URL.Query().Get("stream") != "logs" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		if r.Header.Get("X-API-Token") != "test-api-key" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Unexpected headers: %v", r.Header)
+		}
+
+		mu.Lock()
+		connections++
+		n := connections
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if n == 1 {
+			// Two events, the second spanning two data lines, then the server hangs up
+			fmt.Fprint(w, ": keep-alive\n\nretry: 10\nid: 1\nevent: message\ndata: first\n\nid: 2\ndata: second\ndata: line\n\n")
+			return
+		}
+		fmt.Fprint(w, "id: 3\ndata: third\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errs []error
+	events, err := client.StreamEvents(ctx, LogStream,
+		WithStreamBackoff(time.Millisecond, 5*time.Millisecond),
+		WithStreamErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var received []Event
+	for len(received) < 3 {
+		select {
+		case e := <-events:
+			received = append(received, e)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for events, got %+v", received)
+		}
+	}
+
+	if received[0].ID != "1" || received[0].Event != "message" || string(received[0].Data) != "first" || received[0].Retry != 10*time.Millisecond {
+		t.Errorf("Unexpected first event: %+v", received[0])
+	}
+	if received[1].ID != "2" || string(received[1].Data) != "second\nline" {
+		t.Errorf("Unexpected second event: %+v", received[1])
+	}
+	if received[2].ID != "3" || string(received[2].Data) != "third" {
+		t.Errorf("Unexpected third event: %+v", received[2])
+	}
+
+	cancel()
+	for range events {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastEventIDs) < 2 || lastEventIDs[0] != "" || lastEventIDs[1] != "2" {
+		t.Errorf("Expected reconnect to resume from event 2, got Last-Event-IDs %v", lastEventIDs)
+	}
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), "closed") {
+		t.Errorf("Expected stream close to be reported, got %v", errs)
+	}
+}
+
+func TestStreamEvents_InitialError(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, "unauthorized")
+	})
+
+	if _, err := client.StreamEvents(context.Background(), LogStream); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"time":"2024-05-01T10:00:00Z","level":"WRN","message":"filter rejected","module":"filter","release":"Show.S01E01"}`+"\n\n")
+		fmt.Fprint(w, "data: plain text message\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries, err := client.StreamLogs(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entry := <-entries
+	if entry.Level != LogLevelWarn || entry.Message != "filter rejected" || entry.Module != "filter" || entry.Fields["release"] != "Show.S01E01" {
+		t.Errorf("Unexpected log entry: %+v", entry)
+	}
+	if !entry.Time.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected log time: %v", entry.Time)
+	}
+
+	if entry := <-entries; entry.Message != "plain text message" {
+		t.Errorf("Expected raw data as message, got %+v", entry)
+	}
+
+	cancel()
+	for range entries {
+	}
+}
+
+func TestWithStreamBackoff_Invalid(t *testing.T) {
+	tests := []struct {
+		min, max         time.Duration
+		wantMin, wantMax time.Duration
+	}{
+		{0, 0, time.Second, 30 * time.Second},
+		{-time.Second, 5 * time.Second, time.Second, 5 * time.Second},
+		{10 * time.Second, time.Second, 10 * time.Second, 10 * time.Second},
+		{time.Millisecond, 5 * time.Millisecond, time.Millisecond, 5 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		cfg := newStreamConfig([]StreamOption{WithStreamBackoff(tt.min, tt.max)})
+		if cfg.minBackoff != tt.wantMin || cfg.maxBackoff != tt.wantMax {
+			t.Errorf("WithStreamBackoff(%v, %v) = %v, %v, expected %v, %v", tt.min, tt.max, cfg.minBackoff, cfg.maxBackoff, tt.wantMin, tt.wantMax)
+		}
+	}
+}
@@ -57,7 +57,11 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 		handler(req)
 	}
 
-	resp := m.responses[req.URL.Path]
+	// Responses keyed by "METHOD /path" take precedence over path-only keys
+	resp, ok := m.responses[req.Method+" "+req.URL.Path]
+	if !ok {
+		resp = m.responses[req.URL.Path]
+	}
 	return &http.Response{
 		StatusCode: resp.statusCode,
 		Body:       io.NopCloser(strings.NewReader(resp.responseBody)),
@@ -0,0 +1,79 @@
+package autobrr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// DialContextFunc dials the connections used to reach the server, with the
+// signature of net.Dialer.DialContext
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// unixSocketHost is the host used in request URLs when connecting through a Unix socket
+const unixSocketHost = "unix"
+
+// WithDialContext makes the client dial its connections with dial, e.g. to go
+// through a SOCKS proxy from golang.org/x/net/proxy. The transport of the
+// http.Client set with WithHTTPClient is copied, so the caller's client is left
+// untouched; it must be an *http.Transport.
+func WithDialContext(dial DialContextFunc) Option {
+	return func(c *Client) {
+		c.dialContext = dial
+		c.unixSocket = ""
+	}
+}
+
+// WithUnixSocket connects to the server through the Unix domain socket at path
+// instead of TCP. addr and port are then only used for the Host header and may
+// be empty. It replaces any dialer set with WithDialContext.
+func WithUnixSocket(path string) Option {
+	return func(c *Client) {
+		c.unixSocket = path
+		c.dialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", path)
+		}
+	}
+}
+
+// baseURLFor builds the base URL requests are resolved against
+func baseURLFor(addr, port string, unixSocket bool) string {
+	if unixSocket && addr == "" {
+		addr = unixSocketHost
+	}
+	if port == "" {
+		return "http://" + addr
+	}
+
+	return fmt.Sprintf("http://%s:%s", addr, port)
+}
+
+// applyDialer installs the custom dialer on a copy of the client's http.Client
+func (c *Client) applyDialer() error {
+	if c.dialContext == nil {
+		return nil
+	}
+
+	var transport *http.Transport
+	switch rt := c.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = rt.Clone()
+	default:
+		return fmt.Errorf("custom dialer requires an *http.Transport, got %T", rt)
+	}
+	transport.DialContext = c.dialContext
+	if c.unixSocket != "" {
+		// A proxy from the environment would be dialed through the socket instead of the server
+		transport.Proxy = nil
+	}
+
+	httpClient := *c.client
+	httpClient.Transport = transport
+	c.client = &httpClient
+
+	return nil
+}
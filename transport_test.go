@@ -0,0 +1,131 @@
+package autobrr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithUnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, so avoid the long t.TempDir
+	dir, err := os.MkdirTemp("", "autobrr")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "autobrr.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets not available: %v", err)
+	}
+
+	var requested string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.RequestURI()
+		if r.Header.Get("X-API-Token") != "test-api-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"name":"Test Filter"}]`)
+	}))
+	srv.Listener = listener
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	client, err := NewClientWithOptions("test-api-key", "", "", WithUnixSocket(socket))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if client.baseURL != "http://unix" {
+		t.Errorf("Expected placeholder base URL, got %q", client.baseURL)
+	}
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filters) != 1 || filters[0].Name != "Test Filter" {
+		t.Errorf("Unexpected filters: %+v", filters)
+	}
+
+	// Escaped path segments and queries are kept
+	rc, err := client.OpenRaw(context.Background(), "/api/logs/files/a%2Fb.log?limit=5")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rc.Close()
+	if requested != "/api/logs/files/a%2Fb.log?limit=5" {
+		t.Errorf("Unexpected request URI %q", requested)
+	}
+}
+
+func TestWithDialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	}))
+	t.Cleanup(srv.Close)
+
+	var dials int32
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	httpClient := &http.Client{}
+	client, err := NewClientWithOptions("test-api-key", "autobrr.internal", "7474", WithHTTPClient(httpClient), WithDialContext(dial))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.GetFilters(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if atomic.LoadInt32(&dials) == 0 {
+		t.Error("Expected the custom dialer to be used")
+	}
+
+	// The caller's http.Client is not modified
+	if httpClient.Transport != nil {
+		t.Errorf("Expected the caller's transport to be untouched, got %T", httpClient.Transport)
+	}
+}
+
+func TestWithDialContext_UnsupportedTransport(t *testing.T) {
+	httpClient := &http.Client{Transport: &mockRoundTripper{}}
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, fmt.Errorf("not called")
+	}
+
+	if _, err := NewClientWithOptions("test-api-key", "localhost", "7474", WithHTTPClient(httpClient), WithDialContext(dial)); err == nil {
+		t.Fatal("Expected error, got none")
+	}
+}
+
+func TestBaseURLFor(t *testing.T) {
+	tests := []struct {
+		addr, port string
+		unixSocket bool
+		expected   string
+	}{
+		{"localhost", "7474", false, "http://localhost:7474"},
+		{"autobrr", "", false, "http://autobrr"},
+		{"", "", true, "http://unix"},
+		{"autobrr", "7474", true, "http://autobrr:7474"},
+	}
+
+	for _, tt := range tests {
+		if got := baseURLFor(tt.addr, tt.port, tt.unixSocket); got != tt.expected {
+			t.Errorf("baseURLFor(%q, %q, %v) = %q, expected %q", tt.addr, tt.port, tt.unixSocket, got, tt.expected)
+		}
+	}
+}